@@ -0,0 +1,302 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/fs"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ken00535/validator/pkg/validator"
+)
+
+const validatorPath = "github.com/ken00535/validator/pkg/validator"
+
+// sanitizeMethods map the field type to the method of validator.Fields, other types are decoded
+// by JSON. The types of package are qualified by import path, so aliased imports are matched
+var sanitizeMethods = map[string]string{
+	"int":            "Int",
+	"uint32":         "Uint32",
+	"float64":        "Float64",
+	"bool":           "Bool",
+	"string":         "String",
+	"net.IP":         "IP",
+	"time.Time":      "Time",
+	"time.Duration":  "Duration",
+	"*time.Location": "Location",
+}
+
+// checkMethods map the field type to the method of validator.Checks, other types are checked
+// by existence
+var checkMethods = map[string]string{
+	"int":     "Int",
+	"int32":   "Int32",
+	"int64":   "Int64",
+	"uint32":  "Uint32",
+	"uint64":  "Uint64",
+	"float64": "Float",
+	"bool":    "Bool",
+	"string":  "String",
+	"[]byte":  "Bytes",
+}
+
+// field is the field of struct with vld tag, typeName is the type qualified by import path,
+// like "time.Time" of tm.Time when time is imported as tm. The name of field promoted from
+// embedded struct is the path of selector, like "base.ID"
+type field struct {
+	name     string
+	typeName string
+	param    string
+	options  []string
+}
+
+// structType is the struct declared in package, imports map the names of imports in its file to
+// their paths
+type structType struct {
+	st      *ast.StructType
+	imports map[string]string
+}
+
+// generate parse the package in dir and generate the functions of types
+func generate(dir string, types []string) ([]byte, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	var pkg *ast.Package
+	for _, p := range pkgs {
+		pkg = p
+	}
+	if pkg == nil || len(pkgs) != 1 {
+		return nil, fmt.Errorf("%s should have exactly one package", dir)
+	}
+	structs := map[string]structType{}
+	for _, file := range pkg.Files {
+		imports := fileImports(file)
+		ast.Inspect(file, func(node ast.Node) bool {
+			if spec, ok := node.(*ast.TypeSpec); ok {
+				if st, ok := spec.Type.(*ast.StructType); ok {
+					structs[spec.Name.Name] = structType{st: st, imports: imports}
+				}
+			}
+			return true
+		})
+	}
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "// Code generated by vldgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(buf, "package %s\n\n", pkg.Name)
+	fmt.Fprintf(buf, "import %q\n", validatorPath)
+	for _, typeName := range types {
+		st, ok := structs[typeName]
+		if !ok {
+			return nil, fmt.Errorf("struct %s is not found in %s", typeName, dir)
+		}
+		fields, err := parseFields(fset, st, structs, "")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", typeName, err)
+		}
+		writeSanitize(buf, typeName, fields)
+		writeCheck(buf, typeName, fields)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting output: %w", err)
+	}
+	return src, nil
+}
+
+// fileImports get the names of imports in file and their paths, like "tm" of import tm "time"
+func fileImports(file *ast.File) map[string]string {
+	imports := map[string]string{}
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = importPath
+	}
+	return imports
+}
+
+// parseFields get the fields with vld tag and check their options. The tagged fields of untagged
+// embedded struct declared in package are promoted like SanitizeType, the shallower field wins
+// when params are the same, and the embedded pointer is not promoted because it may be nil
+func parseFields(fset *token.FileSet, st structType, structs map[string]structType, prefix string) ([]field, error) {
+	fields := []field{}
+	for _, f := range st.st.Fields.List {
+		tag := ""
+		if f.Tag != nil {
+			var err error
+			if tag, err = strconv.Unquote(f.Tag.Value); err != nil {
+				return nil, err
+			}
+		}
+		parts := strings.Split(reflect.StructTag(tag).Get("vld"), ",")
+		if parts[0] == "" {
+			if ident, ok := f.Type.(*ast.Ident); ok && len(f.Names) == 0 {
+				if embedded, ok := structs[ident.Name]; ok {
+					embeddedFields, err := parseFields(fset, embedded, structs, prefix+ident.Name+".")
+					if err != nil {
+						return nil, err
+					}
+					fields = append(fields, embeddedFields...)
+				}
+			}
+			continue
+		}
+		typeName := exprString(fset, f.Type)
+		names := []string{strings.TrimPrefix(typeName[strings.LastIndex(typeName, ".")+1:], "*")}
+		if len(f.Names) > 0 {
+			names = names[:0]
+			for _, name := range f.Names {
+				names = append(names, name.Name)
+			}
+		}
+		for _, name := range names {
+			pos := fset.Position(f.Pos())
+			if !ast.IsExported(name) {
+				return nil, fmt.Errorf("%v: field %s tagged %s is unexported", pos, name, parts[0])
+			}
+			for _, option := range parts[1:] {
+				if !validator.IsTagOption(option) {
+					return nil, fmt.Errorf("%v: field %s has unknown option %q", pos, name, option)
+				}
+				if strings.HasPrefix(option, "tz=") {
+					if _, err := time.LoadLocation(strings.TrimPrefix(option, "tz=")); err != nil {
+						return nil, fmt.Errorf("%v: field %s has unknown time zone %q", pos, name, option)
+					}
+				}
+			}
+			fields = append(fields, field{name: prefix + name, typeName: qualifiedType(fset, f.Type, st.imports), param: parts[0], options: parts[1:]})
+		}
+	}
+	return shallowest(fields), nil
+}
+
+// shallowest keep the shallowest field of each param in the order of their first fields, like
+// the promoted fields of go
+func shallowest(fields []field) []field {
+	byParam := map[string]int{}
+	result := []field{}
+	for _, f := range fields {
+		i, ok := byParam[f.param]
+		if !ok {
+			byParam[f.param] = len(result)
+			result = append(result, f)
+			continue
+		}
+		if strings.Count(f.name, ".") < strings.Count(result[i].name, ".") {
+			result[i] = f
+		}
+	}
+	return result
+}
+
+func writeSanitize(buf *bytes.Buffer, typeName string, fields []field) {
+	fmt.Fprintf(buf, "\n// Sanitize%s sanitize payload into %s by its vld tags and run its struct-level rules\n", exported(typeName), typeName)
+	fmt.Fprintf(buf, "func Sanitize%s(payload validator.Payload) (%s, error) {\n", exported(typeName), typeName)
+	fmt.Fprintf(buf, "var out %s\n", typeName)
+	fmt.Fprintf(buf, "fields := validator.NewFields(payload)\n")
+	for _, f := range fields {
+		typeName := f.typeName
+		pointer := strings.HasPrefix(typeName, "*") && typeName != "*time.Location"
+		if pointer {
+			typeName = typeName[1:]
+		}
+		method, ok := sanitizeMethods[typeName]
+		if !ok {
+			fmt.Fprintf(buf, "fields.JSON(%q, &out.%s%s)\n", f.param, f.name, optionArgs(f.options))
+			continue
+		}
+		fmt.Fprintf(buf, "if val, ok := fields.%s(%q%s); ok {\n", method, f.param, optionArgs(f.options))
+		if pointer {
+			fmt.Fprintf(buf, "out.%s = &val\n}\n", f.name)
+		} else {
+			fmt.Fprintf(buf, "out.%s = val\n}\n", f.name)
+		}
+	}
+	fmt.Fprintf(buf, "fields.Validate(&out)\n")
+	fmt.Fprintf(buf, "return out, fields.Err()\n}\n")
+}
+
+func writeCheck(buf *bytes.Buffer, typeName string, fields []field) {
+	fmt.Fprintf(buf, "\n// Check%s check types of payload by the vld tags of %s\n", exported(typeName), typeName)
+	fmt.Fprintf(buf, "func Check%s(payload validator.Payload) error {\n", exported(typeName))
+	fmt.Fprintf(buf, "checks := validator.NewChecks(payload)\n")
+	for _, f := range fields {
+		method, ok := checkMethods[f.typeName]
+		if !ok {
+			method = "Exist"
+		}
+		optional := ""
+		if isOptional(f.options) {
+			optional = `, "optional"`
+		}
+		fmt.Fprintf(buf, "checks.%s(%q%s)\n", method, f.param, optional)
+	}
+	fmt.Fprintf(buf, "return checks.Err()\n}\n")
+}
+
+func optionArgs(options []string) string {
+	args := ""
+	for _, option := range options {
+		args += ", " + strconv.Quote(option)
+	}
+	return args
+}
+
+func isOptional(options []string) bool {
+	for _, option := range options {
+		if option == "optional" {
+			return true
+		}
+	}
+	return false
+}
+
+// exported upper the first letter of type name, so the functions of unexported type are exported
+func exported(typeName string) string {
+	return strings.ToUpper(typeName[:1]) + typeName[1:]
+}
+
+// qualifiedType get the type of expr whose package is named by import path, like "*time.Location"
+// of *tm.Location, so the types are matched no matter how the package is imported
+func qualifiedType(fset *token.FileSet, expr ast.Expr, imports map[string]string) string {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return "*" + qualifiedType(fset, expr.X, imports)
+	case *ast.ArrayType:
+		if expr.Len == nil {
+			return "[]" + qualifiedType(fset, expr.Elt, imports)
+		}
+	case *ast.SelectorExpr:
+		if pkg, ok := expr.X.(*ast.Ident); ok {
+			if importPath, ok := imports[pkg.Name]; ok {
+				return importPath + "." + expr.Sel.Name
+			}
+		}
+	}
+	return exprString(fset, expr)
+}
+
+func exprString(fset *token.FileSet, expr ast.Expr) string {
+	buf := &bytes.Buffer{}
+	printer.Fprint(buf, fset, expr)
+	return buf.String()
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	src, err := generate("testdata/user", []string{"user"})
+	assert.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "user_vld.go", src, 0)
+	assert.NoError(t, err)
+	code := string(src)
+	assert.Contains(t, code, "// Code generated by vldgen. DO NOT EDIT.")
+	assert.Contains(t, code, "func SanitizeUser(payload validator.Payload) (user, error) {")
+	assert.Contains(t, code, "func CheckUser(payload validator.Payload) error {")
+	assert.Contains(t, code, `if val, ok := fields.String("name", "trimspace"); ok {`)
+	assert.Contains(t, code, `if val, ok := fields.Int("age", "optional"); ok {`)
+	assert.Contains(t, code, "out.Age = &val")
+	assert.Contains(t, code, `fields.IP("ip")`)
+	assert.Contains(t, code, `fields.Time("start", "tz=Asia/Taipei")`)
+	assert.Contains(t, code, `fields.Location("zone")`)
+	assert.Contains(t, code, `fields.JSON("leg", &out.Leg)`)
+	assert.Contains(t, code, `checks.String("name")`)
+	assert.Contains(t, code, `checks.Exist("age", "optional")`)
+	assert.NotContains(t, code, "Ignored")
+}
+
+func TestGeneratePromoted(t *testing.T) {
+	src, err := generate("testdata/user", []string{"item"})
+	assert.NoError(t, err)
+	code := string(src)
+	assert.Contains(t, code, `if val, ok := fields.Int("id"); ok {`)
+	assert.Contains(t, code, "out.base.ID = val")
+	assert.Contains(t, code, `if val, ok := fields.String("baseName", "trimspace"); ok {`)
+	assert.Contains(t, code, "out.Name = val")
+	assert.Contains(t, code, `checks.Int("id")`)
+	assert.NotContains(t, code, "out.base.Name")
+	assert.NotContains(t, code, `"by"`)
+}
+
+// TestGenerateExample check the generated code of internal/example is up to date, its tests
+// compile and run the code against ToStruct
+func TestGenerateExample(t *testing.T) {
+	src, err := generate("internal/example", []string{"user"})
+	assert.NoError(t, err)
+	want, err := os.ReadFile("internal/example/user_vld.go")
+	assert.NoError(t, err)
+	assert.Equal(t, string(want), string(src))
+	code := string(src)
+	assert.Contains(t, code, `fields.Time("start", "tz=Asia/Taipei")`)
+	assert.Contains(t, code, `fields.Duration("timeout", "optional")`)
+	assert.Contains(t, code, `fields.Location("zone")`)
+}
+
+func TestGenerateError(t *testing.T) {
+	type testCase struct {
+		typeName string
+		errMsg   string
+	}
+	cases := []testCase{
+		{typeName: "user", errMsg: `field Name has unknown option "trimspcae"`},
+		{typeName: "zone", errMsg: `field Start has unknown time zone "tz=Asia/Nowhere"`},
+		{typeName: "hidden", errMsg: "field name tagged name is unexported"},
+		{typeName: "missing", errMsg: "struct missing is not found in testdata/typo"},
+	}
+	for _, c := range cases {
+		_, err := generate("testdata/typo", []string{c.typeName})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), c.errMsg)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+// Package example is the structs sanitized by the code generated by vldgen, its tests compare the
+// generated sanitizers with ToStruct
+package example
+
+import (
+	"net"
+	tm "time"
+
+	"github.com/ken00535/validator/pkg/validator"
+)
+
+//go:generate go run github.com/ken00535/validator/cmd/vldgen -type user
+
+type toe struct {
+	Length float64 `vld:"length"`
+}
+
+type leg struct {
+	Number int    `vld:"number"`
+	Color  string `vld:"color,optional,trimspace,lower"`
+	BigToe *toe   `vld:"bigToe,optional"`
+	Note   string
+}
+
+type meta struct {
+	ID int `vld:"id"`
+}
+
+type user struct {
+	meta
+	Name    string       `vld:"name,trimspace"`
+	Age     *int         `vld:"age,optional"`
+	Score   float64      `vld:"score,optional"`
+	Alive   bool         `vld:"alive,optional"`
+	IP      net.IP       `vld:"ip"`
+	Start   tm.Time      `vld:"start,tz=Asia/Taipei"`
+	Timeout tm.Duration  `vld:"timeout,optional"`
+	Zone    *tm.Location `vld:"zone"`
+	Leg     leg          `vld:"leg"`
+	Tags    []string     `vld:"tags,optional"`
+	Ignored string
+}
+
+func (u *user) Validate() error {
+	if u.Score > 100 {
+		return validator.NewInvalidStructError("score should not be greater than 100", "Score")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Code generated by vldgen. DO NOT EDIT.
+
+package example
+
+import "github.com/ken00535/validator/pkg/validator"
+
+// SanitizeUser sanitize payload into user by its vld tags and run its struct-level rules
+func SanitizeUser(payload validator.Payload) (user, error) {
+	var out user
+	fields := validator.NewFields(payload)
+	if val, ok := fields.Int("id"); ok {
+		out.meta.ID = val
+	}
+	if val, ok := fields.String("name", "trimspace"); ok {
+		out.Name = val
+	}
+	if val, ok := fields.Int("age", "optional"); ok {
+		out.Age = &val
+	}
+	if val, ok := fields.Float64("score", "optional"); ok {
+		out.Score = val
+	}
+	if val, ok := fields.Bool("alive", "optional"); ok {
+		out.Alive = val
+	}
+	if val, ok := fields.IP("ip"); ok {
+		out.IP = val
+	}
+	if val, ok := fields.Time("start", "tz=Asia/Taipei"); ok {
+		out.Start = val
+	}
+	if val, ok := fields.Duration("timeout", "optional"); ok {
+		out.Timeout = val
+	}
+	if val, ok := fields.Location("zone"); ok {
+		out.Zone = val
+	}
+	fields.JSON("leg", &out.Leg)
+	fields.JSON("tags", &out.Tags, "optional")
+	fields.Validate(&out)
+	return out, fields.Err()
+}
+
+// CheckUser check types of payload by the vld tags of user
+func CheckUser(payload validator.Payload) error {
+	checks := validator.NewChecks(payload)
+	checks.Int("id")
+	checks.String("name")
+	checks.Exist("age", "optional")
+	checks.Float("score", "optional")
+	checks.Bool("alive", "optional")
+	checks.Exist("ip")
+	checks.Exist("start")
+	checks.Exist("timeout", "optional")
+	checks.Exist("zone")
+	checks.Exist("leg")
+	checks.Exist("tags", "optional")
+	return checks.Err()
+}
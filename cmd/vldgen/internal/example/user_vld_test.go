@@ -0,0 +1,150 @@
+package example
+
+import (
+	"testing"
+
+	"github.com/ken00535/validator/pkg/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+type message struct {
+	msg   map[string]interface{}
+	cache map[string]interface{}
+}
+
+func (m *message) GetCache() map[string]interface{} {
+	if m.cache == nil {
+		m.cache = make(map[string]interface{})
+	}
+	return m.cache
+}
+
+func (m *message) SetCache(input map[string]interface{}) {
+	m.cache = input
+}
+
+func (m *message) GetParam(field string) (val interface{}, exist bool) {
+	v, ok := m.msg[field]
+	return v, ok
+}
+
+func TestSanitizeUser(t *testing.T) {
+	type testCase struct {
+		msg       map[string]interface{}
+		errsCount int
+	}
+	cases := []testCase{
+		{
+			msg: map[string]interface{}{
+				"id":      "7",
+				"name":    " ken ",
+				"age":     "18",
+				"score":   "90.5",
+				"alive":   "true",
+				"ip":      "127.0.0.1",
+				"start":   "2020-11-06T16:19:23Z",
+				"timeout": "PT1H30M",
+				"zone":    "Asia/Taipei",
+				"leg":     `{"number": 2, "color": " RED ", "bigToe": {"length": 1.5}, "Note": "left"}`,
+				"tags":    `["a", "b"]`,
+			},
+			errsCount: 0,
+		},
+		{
+			msg: map[string]interface{}{
+				"id":    "7",
+				"name":  "ken",
+				"score": "120",
+				"ip":    "127.0.0.1",
+				"start": "2020-11-06T16:19:23Z",
+				"zone":  "Asia/Taipei",
+				"leg":   `{"number": 2}`,
+			},
+			errsCount: 1,
+		},
+		{
+			msg: map[string]interface{}{
+				"name":  "ken",
+				"ip":    "127.0.0.1",
+				"start": "2020-11-06T16:19:23Z",
+				"zone":  "Asia/Taipei",
+				"leg":   `{"number": "two", "color": 5, "bigToe": {"length": "long"}}`,
+			},
+			errsCount: 4,
+		},
+		{
+			msg: map[string]interface{}{
+				"age":     "18A",
+				"score":   "high",
+				"alive":   "maybe",
+				"ip":      "localhost",
+				"start":   "2020-11-06",
+				"timeout": "1e12",
+				"zone":    "Nowhere/Bad",
+				"leg":     `[2]`,
+				"tags":    `{"a": 1}`,
+			},
+			errsCount: 11,
+		},
+	}
+	for _, tc := range cases {
+		generatedPayload := &message{msg: tc.msg}
+		generated, err := SanitizeUser(generatedPayload)
+		generatedErrs, generatedAbsence := validator.ValidateResult(generatedPayload)
+
+		reflectedPayload := &message{msg: tc.msg}
+		reflected := user{}
+		for _, param := range []string{"id", "name", "age", "score", "alive", "ip", "start", "timeout", "zone", "leg", "tags"} {
+			sanitizeByTag(reflectedPayload, param, &reflected)
+		}
+		reflectedErrs, reflectedAbsence := validator.ValidateResult(reflectedPayload)
+
+		assert.Equal(t, tc.errsCount, len(generatedErrs))
+		assert.Equal(t, errorMessages(reflectedErrs), errorMessages(generatedErrs))
+		assert.Equal(t, reflectedAbsence, generatedAbsence)
+		assert.Equal(t, reflected, generated)
+		if tc.errsCount > 0 {
+			assert.EqualError(t, err, reflectedErrs[0].Error())
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
+// sanitizeByTag sanitize param into user by the chain of its field type, it is what the generated
+// SanitizeUser should do without reflection
+func sanitizeByTag(payload validator.Payload, param string, out *user) {
+	session := validator.Sanitize(payload)
+	switch param {
+	case "id":
+		session.Params(param).ToInt(out)
+	case "name":
+		session.Params(param).ToString(out)
+	case "age":
+		session.Params(param).Optional().ToInt(out)
+	case "score":
+		session.Params(param).Optional().ToFloat64(out)
+	case "alive":
+		session.Params(param).Optional().ToBool(out)
+	case "ip":
+		session.Params(param).ToIP(out)
+	case "start":
+		session.Params(param).ToTime(out)
+	case "timeout":
+		session.Params(param).Optional().ToDuration(out)
+	case "zone":
+		session.Params(param).ToLocation(out)
+	case "leg":
+		session.Params(param).ToStruct(out)
+	case "tags":
+		session.Params(param).Optional().ToStruct(out)
+	}
+}
+
+func errorMessages(errs []error) []string {
+	messages := []string{}
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
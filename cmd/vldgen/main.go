@@ -0,0 +1,47 @@
+// Command vldgen generate reflection-free sanitizers of structs with vld tags.
+//
+// For each type, it emits Sanitize<Type>(payload validator.Payload) (<Type>, error) and
+// Check<Type>(payload validator.Payload) error, which sanitize and check the params like
+// SanitizeType and CheckType do with the struct. It is used with go generate
+//
+//	//go:generate vldgen -type user,booking
+//
+// The tagged fields of untagged embedded structs declared in the package are promoted like
+// SanitizeType. The unknown options and time zones of tags are reported when generating.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("vldgen: ")
+	typeNames := flag.String("type", "", "comma-separated list of type names, must be set")
+	output := flag.String("output", "", "output file name, default is <type>_vld.go")
+	flag.Parse()
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+	types := strings.Split(*typeNames, ",")
+	src, err := generate(dir, types)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *output == "" {
+		*output = filepath.Join(dir, strings.ToLower(types[0])+"_vld.go")
+	}
+	if err := os.WriteFile(*output, src, 0644); err != nil {
+		log.Fatal(fmt.Errorf("writing output: %w", err))
+	}
+}
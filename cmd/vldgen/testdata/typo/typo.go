@@ -0,0 +1,13 @@
+package typo
+
+type user struct {
+	Name string `vld:"name,trimspcae"`
+}
+
+type zone struct {
+	Start string `vld:"start,tz=Asia/Nowhere"`
+}
+
+type hidden struct {
+	name string `vld:"name"`
+}
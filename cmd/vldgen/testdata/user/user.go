@@ -0,0 +1,35 @@
+package user
+
+import (
+	"net"
+	"time"
+)
+
+type leg struct {
+	Number int `vld:"number"`
+}
+
+type user struct {
+	Name    string         `vld:"name,trimspace"`
+	Age     *int           `vld:"age,optional"`
+	IP      net.IP         `vld:"ip"`
+	Start   time.Time      `vld:"start,tz=Asia/Taipei"`
+	Zone    *time.Location `vld:"zone"`
+	Leg     leg            `vld:"leg"`
+	Ignored string
+}
+
+type base struct {
+	ID   int    `vld:"id"`
+	Name string `vld:"baseName"`
+}
+
+type audit struct {
+	By string `vld:"by"`
+}
+
+type item struct {
+	base
+	*audit
+	Name string `vld:"baseName,trimspace"`
+}
@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldDescription describe the tagged field of struct
+type FieldDescription struct {
+	// Param is the name of vld tag, and Options are the options following it
+	Param   string
+	Options []string
+	// Name is the go field name, and Index is the index path for reflect.Value.FieldByIndex
+	Name     string
+	Index    []int
+	Type     reflect.Type
+	Exported bool
+	// Promoted means the field is promoted from embedded struct
+	Promoted bool
+	// Children are the tagged fields of struct field, or of the struct elements of slice field
+	Children []FieldDescription
+}
+
+// Describe get every tagged field of the struct, the shadowed fields of embedded structs are not
+// included. It returns empty slice when the content isn't struct or pointer to struct
+func (v *AnalyzeType) Describe() []FieldDescription {
+	structType := structTypeOf(v.content)
+	if structType == nil {
+		return []FieldDescription{}
+	}
+	return describeStruct(structType, map[reflect.Type]bool{})
+}
+
+// ParamOf get the param of field by go field name, the name can be the path of nested field
+// like "Foot.Size". It reports false when the field isn't tagged
+func (v *AnalyzeType) ParamOf(fieldName string) (string, bool) {
+	fields := v.Describe()
+	params := []string{}
+	for _, name := range strings.Split(fieldName, ".") {
+		var found *FieldDescription
+		for i, field := range fields {
+			// the shallower field wins like the promoted fields of go
+			if field.Name == name && (found == nil || len(field.Index) < len(found.Index)) {
+				found = &fields[i]
+			}
+		}
+		if found == nil {
+			return "", false
+		}
+		params = append(params, found.Param)
+		fields = found.Children
+	}
+	return strings.Join(params, "."), true
+}
+
+func describeStruct(structType reflect.Type, visited map[reflect.Type]bool) []FieldDescription {
+	visited[structType] = true
+	defer delete(visited, structType)
+	info := structInfoOf(structType)
+	fields := make([]FieldDescription, 0, len(info.tags))
+	for _, param := range info.tags {
+		field := info.byParam[param]
+		description := FieldDescription{
+			Param:    field.param,
+			Options:  append([]string{}, field.options...),
+			Name:     field.name,
+			Index:    append([]int{}, field.index...),
+			Type:     field.fieldType,
+			Exported: field.exported,
+			Promoted: field.promoted,
+		}
+		childType := field.fieldType
+		for childType.Kind() == reflect.Ptr || childType.Kind() == reflect.Slice || childType.Kind() == reflect.Array {
+			childType = childType.Elem()
+		}
+		if isTaggedStruct(childType) && !visited[childType] {
+			description.Children = describeStruct(childType, visited)
+		}
+		fields = append(fields, description)
+	}
+	return fields
+}
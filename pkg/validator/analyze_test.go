@@ -0,0 +1,88 @@
+package validator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type auditFields struct {
+	ID      int    `vld:"id"`
+	Creator string `vld:"creator,optional"`
+}
+
+type order struct {
+	auditFields
+	ID    string  `vld:"orderId"`
+	Owner body    `vld:"owner"`
+	Feet  []*foot `vld:"feet,optional"`
+	Next  *order  `vld:"next,optional"`
+	note  string  `vld:"note"`
+}
+
+func TestAnalyzeDescribe(t *testing.T) {
+	fields := Analyze(&order{}).Describe()
+	params := []string{}
+	for _, field := range fields {
+		params = append(params, field.Param)
+	}
+	assert.Equal(t, []string{"id", "creator", "orderId", "owner", "feet", "next", "note"}, params)
+
+	assert.Equal(t, FieldDescription{
+		Param:    "creator",
+		Options:  []string{"optional"},
+		Name:     "Creator",
+		Index:    []int{0, 1},
+		Type:     reflect.TypeOf(""),
+		Exported: true,
+		Promoted: true,
+	}, fields[1])
+	assert.Equal(t, []int{1}, fields[2].Index)
+	assert.False(t, fields[6].Exported)
+
+	owner := fields[3]
+	assert.Equal(t, "foot", owner.Children[0].Param)
+	assert.Equal(t, []string{"size", "color", "bought", "shop", "bigToe"}, describedParams(owner.Children[0].Children))
+	assert.Equal(t, "length", owner.Children[0].Children[4].Children[0].Param)
+	assert.Equal(t, 5, len(fields[4].Children))
+	assert.Equal(t, 0, len(fields[5].Children))
+
+	assert.Equal(t, []FieldDescription{}, Analyze(3).Describe())
+	assert.Equal(t, []FieldDescription{}, Analyze(nil).Describe())
+}
+
+func TestAnalyzeParamOf(t *testing.T) {
+	type testCase struct {
+		fieldName string
+		want      string
+		ok        bool
+	}
+	cases := []testCase{
+		{fieldName: "ID", want: "orderId", ok: true},
+		{fieldName: "Creator", want: "creator", ok: true},
+		{fieldName: "Owner.Foot.BigToe.Length", want: "owner.foot.bigToe.length", ok: true},
+		{fieldName: "Feet.Size", want: "feet.size", ok: true},
+		{fieldName: "Owner.Foot.Comment", want: "", ok: false},
+		{fieldName: "Missing", want: "", ok: false},
+	}
+	for _, c := range cases {
+		param, ok := Analyze(order{}).ParamOf(c.fieldName)
+		assert.Equal(t, c.want, param)
+		assert.Equal(t, c.ok, ok)
+	}
+}
+
+func TestAnalyzeNotStruct(t *testing.T) {
+	assert.Equal(t, []string{}, Analyze(3).Fields([]string{"id"}))
+	assert.Equal(t, []string{"Creator", "ID"}, Analyze(&order{}).Fields([]string{"creator", "orderId"}))
+	assert.Equal(t, []string{}, Analyze("id").Tags())
+}
+
+func describedParams(fields []FieldDescription) []string {
+	params := []string{}
+	for _, field := range fields {
+		params = append(params, field.Param)
+	}
+	return params
+}
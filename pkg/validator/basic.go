@@ -1,16 +1,25 @@
 package validator
 
+import (
+	"time"
+)
+
 type validatorInterface interface {
 	getAbsenceError() error
 	getPayload() Payload
 	getOptional() bool
 	getParam() string
+	skip() bool
+	markInvalid(param string)
 }
 
 type validatorBase struct {
 	content  Payload
 	param    string
 	optional bool
+	bail     bool
+	// prefix is the path of nested param, like "leg." of "leg.number"
+	prefix string
 }
 
 func (v *validatorBase) getPayload() Payload {
@@ -22,7 +31,7 @@ func (v *validatorBase) getOptional() bool {
 }
 
 func (v *validatorBase) getParam() string {
-	return v.param
+	return v.prefix + v.param
 }
 
 func (v *validatorBase) handleErrors(err error) {
@@ -38,10 +47,133 @@ func (v *validatorBase) handleErrors(err error) {
 	if err != nil {
 		errorList = append(errorList, err)
 		cache[contextKey].(map[string]interface{})[errorsKey] = errorList
+		v.markInvalid(v.getParam())
+	}
+}
+
+// mergeResult merge the result of nested session into the session, the params of result
+// should have the path of nested param, like "leg.number"
+func (v *validatorBase) mergeResult(errs []error, absence []string) {
+	cache := v.content.GetCache()
+	absenceList := cache[contextKey].(map[string]interface{})[abcenseKey].([]string)
+	cache[contextKey].(map[string]interface{})[abcenseKey] = append(absenceList, absence...)
+	for _, err := range errs {
+		v.handleErrors(err)
+	}
+}
+
+// inheritSession copy the modes of session to the session of nested payload
+func (v *validatorBase) inheritSession(nested Payload) {
+	cache := v.content.GetCache()
+	nestedCache := nested.GetCache()
+	for _, key := range []string{failFastKey, utcKey, clockKey} {
+		if mode, ok := cache[contextKey].(map[string]interface{})[key]; ok {
+			nestedCache[contextKey].(map[string]interface{})[key] = mode
+		}
+	}
+}
+
+func (v *validatorBase) setParam(param string) {
+	v.param = param
+	v.cover(param)
+}
+
+func (v *validatorBase) cover(params ...string) {
+	cache := v.content.GetCache()
+	covered, _ := cache[contextKey].(map[string]interface{})[coveredKey].(map[string]bool)
+	if covered == nil {
+		covered = make(map[string]bool)
+		cache[contextKey].(map[string]interface{})[coveredKey] = covered
+	}
+	for _, param := range params {
+		covered[param] = true
+	}
+}
+
+func (v *validatorBase) setStrict() {
+	cache := v.content.GetCache()
+	cache[contextKey].(map[string]interface{})[strictKey] = true
+}
+
+// now is the reference time of time-relative rules, it is the time of session clock
+func (v *validatorBase) now() time.Time {
+	cache := v.content.GetCache()
+	if clock, ok := cache[contextKey].(map[string]interface{})[clockKey].(Clock); ok {
+		return clock.Now()
+	}
+	return time.Now()
+}
+
+func (v *validatorBase) setClock(clock Clock) {
+	cache := v.content.GetCache()
+	cache[contextKey].(map[string]interface{})[clockKey] = clock
+}
+
+func (v *validatorBase) setFailFast() {
+	cache := v.content.GetCache()
+	cache[contextKey].(map[string]interface{})[failFastKey] = true
+}
+
+// skip report the following rules should be skipped, because the session is fail-fast
+// and there has been an error, or the chain bails and its param has failed
+func (v *validatorBase) skip() bool {
+	cache := v.content.GetCache()
+	if failFast, _ := cache[contextKey].(map[string]interface{})[failFastKey].(bool); failFast {
+		if errorList, _ := cache[contextKey].(map[string]interface{})[errorsKey].([]error); len(errorList) > 0 {
+			return true
+		}
 	}
+	return v.bail && v.isBroken(v.getParam())
+}
+
+func (v *validatorBase) markInvalid(param string) {
+	cache := v.content.GetCache()
+	invalid, _ := cache[contextKey].(map[string]interface{})[invalidKey].(map[string]bool)
+	if invalid == nil {
+		invalid = make(map[string]bool)
+		cache[contextKey].(map[string]interface{})[invalidKey] = invalid
+	}
+	invalid[param] = true
+}
+
+// markSanitized record param has been sanitized into the target of session
+func (v *validatorBase) markSanitized(param string) {
+	cache := v.content.GetCache()
+	sanitized, _ := cache[contextKey].(map[string]interface{})[sanitizedKey].(map[string]bool)
+	if sanitized == nil {
+		sanitized = make(map[string]bool)
+		cache[contextKey].(map[string]interface{})[sanitizedKey] = sanitized
+	}
+	sanitized[param] = true
+}
+
+// isSanitized report param has been sanitized into the target of session
+func (v *validatorBase) isSanitized(param string) bool {
+	cache := v.content.GetCache()
+	sanitized, _ := cache[contextKey].(map[string]interface{})[sanitizedKey].(map[string]bool)
+	return sanitized[param]
+}
+
+// isBroken report param is absent or has failed a rule in this session
+func (v *validatorBase) isBroken(param string) bool {
+	cache := v.content.GetCache()
+	invalid, _ := cache[contextKey].(map[string]interface{})[invalidKey].(map[string]bool)
+	if invalid[param] {
+		return true
+	}
+	absenceList, _ := cache[contextKey].(map[string]interface{})[abcenseKey].([]string)
+	for _, absence := range absenceList {
+		if absence == param {
+			return true
+		}
+	}
+	return false
 }
 
 func handleAbsence(v validatorInterface) (interface{}, bool) {
+	if v.skip() {
+		return nil, false
+	}
 	cache := v.getPayload().GetCache()
 	errorList := cache[contextKey].(map[string]interface{})[errorsKey].([]error)
 	absenceList := cache[contextKey].(map[string]interface{})[abcenseKey].([]string)
@@ -50,6 +182,7 @@ func handleAbsence(v validatorInterface) (interface{}, bool) {
 		if !v.getOptional() {
 			errorList = append(errorList, v.getAbsenceError())
 			cache[contextKey].(map[string]interface{})[errorsKey] = errorList
+			v.markInvalid(v.getParam())
 		}
 		absenceList = append(absenceList, v.getParam())
 		cache[contextKey].(map[string]interface{})[abcenseKey] = absenceList
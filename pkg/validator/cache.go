@@ -0,0 +1,99 @@
+package validator
+
+import (
+	"reflect"
+	"sync"
+)
+
+// structInfos cache the compiled metadata of struct types, map[reflect.Type]*structInfo
+var structInfos sync.Map
+
+// structInfo is the compiled metadata of struct with vld tags
+type structInfo struct {
+	// fields keep the shadowed fields, use tags and byParam to get the field of each param
+	fields  []fieldInfo
+	byParam map[string]*fieldInfo
+	tags    []string
+}
+
+// fieldInfo is the compiled metadata of field with vld tag
+type fieldInfo struct {
+	name     string
+	param    string
+	options  []string
+	index    []int
+	dataType int
+	exported bool
+	// fieldType is the type of field, and promoted means it is promoted from embedded struct
+	fieldType reflect.Type
+	promoted  bool
+}
+
+// structInfoOf get the metadata of struct type, it is compiled at first use and cached
+func structInfoOf(structType reflect.Type) *structInfo {
+	if info, ok := structInfos.Load(structType); ok {
+		return info.(*structInfo)
+	}
+	info, _ := structInfos.LoadOrStore(structType, compileStruct(structType))
+	return info.(*structInfo)
+}
+
+func compileStruct(structType reflect.Type) *structInfo {
+	info := &structInfo{
+		byParam: make(map[string]*fieldInfo),
+		tags:    []string{},
+	}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		param, options := parseTag(field.Tag.Get(tagName))
+		if param == "" {
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				info.promote(field, structInfoOf(field.Type))
+			}
+			continue
+		}
+		info.fields = append(info.fields, fieldInfo{
+			name:      field.Name,
+			param:     param,
+			options:   options,
+			index:     field.Index,
+			dataType:  dataTypeOf(field.Type),
+			exported:  field.PkgPath == "",
+			fieldType: field.Type,
+		})
+	}
+	for i := range info.fields {
+		field, ok := info.byParam[info.fields[i].param]
+		if !ok {
+			info.tags = append(info.tags, info.fields[i].param)
+		}
+		// the shallower field wins like the promoted fields of go
+		if !ok || len(info.fields[i].index) < len(field.index) {
+			info.byParam[info.fields[i].param] = &info.fields[i]
+		}
+	}
+	return info
+}
+
+// promote add the tagged fields of embedded struct, the embedded pointer is not promoted because
+// it may be nil
+func (info *structInfo) promote(embedded reflect.StructField, embeddedInfo *structInfo) {
+	for _, field := range embeddedInfo.fields {
+		field.index = append(append([]int{}, embedded.Index...), field.index...)
+		field.promoted = true
+		info.fields = append(info.fields, field)
+	}
+}
+
+// structInfoOfTarget get the metadata of target which is struct or pointer to struct,
+// it returns nil for other targets
+func structInfoOfTarget(out interface{}) *structInfo {
+	targetType := reflect.TypeOf(out)
+	if targetType != nil && targetType.Kind() == reflect.Ptr {
+		targetType = targetType.Elem()
+	}
+	if targetType == nil || targetType.Kind() != reflect.Struct {
+		return nil
+	}
+	return structInfoOf(targetType)
+}
@@ -0,0 +1,74 @@
+package validator
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructInfo(t *testing.T) {
+	info := structInfoOf(reflect.TypeOf(foot{}))
+	assert.Equal(t, []string{"size", "color", "bought", "shop", "bigToe"}, info.tags)
+	assert.Equal(t, "Color", info.byParam["color"].name)
+	assert.Equal(t, []string{"optional", "trimspace", "lower"}, info.byParam["color"].options)
+	assert.Equal(t, []int{1}, info.byParam["color"].index)
+	assert.Equal(t, stringType, info.byParam["color"].dataType)
+	assert.Equal(t, timeType, info.byParam["bought"].dataType)
+	assert.Equal(t, ipType, info.byParam["shop"].dataType)
+	assert.Equal(t, objectType, info.byParam["bigToe"].dataType)
+	assert.True(t, info.byParam["size"].exported)
+	assert.Nil(t, structInfoOfTarget(18))
+	assert.Nil(t, structInfoOfTarget(nil))
+}
+
+func TestStructInfoConcurrent(t *testing.T) {
+	type concurrentStruct struct {
+		Age int `vld:"age"`
+	}
+	infos := make([]*structInfo, 8)
+	var wg sync.WaitGroup
+	for i := range infos {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			infos[i] = structInfoOfTarget(&concurrentStruct{})
+		}(i)
+	}
+	wg.Wait()
+	for _, info := range infos {
+		assert.Same(t, infos[0], info)
+	}
+}
+
+func BenchmarkSanitizeField(b *testing.B) {
+	payload := &message{msg: map[string]interface{}{
+		"startTime": "2020-11-06T16:19:23Z",
+		"age":       "18",
+		"name":      "ken",
+	}}
+	actual := testStruct{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		session := Sanitize(payload)
+		session.Params("age").ToInt(&actual)
+		session.Params("name").ToString(&actual)
+		session.Params("startTime").ToTime(&actual)
+		ValidateResult(payload)
+	}
+}
+
+func BenchmarkSanitizeNested(b *testing.B) {
+	payload := &message{msg: map[string]interface{}{
+		"foot": `{"size": 42, "color": "red", "bought": "2020-11-06T16:19:23Z"}`,
+	}}
+	actual := body{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Sanitize(payload).Params("foot").ToStruct(&actual)
+		ValidateResult(payload)
+	}
+}
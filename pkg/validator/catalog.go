@@ -0,0 +1,196 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+var templateArgPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// Catalog is the message templates keyed by error code, the args of error are put into the
+// template by name, like "{param} must be an integer"
+type Catalog map[string]string
+
+var (
+	catalogs   = map[string]Catalog{"en": englishCatalog, "zh-TW": chineseCatalog}
+	catalogsMu sync.RWMutex
+)
+
+var englishCatalog = Catalog{
+	"required":         "{param} is required",
+	"unknown":          "{param} is not allowed",
+	"unsafe":           "{param} must not contain {kind} meta character {meta}",
+	"type":             "{param} must be {type}",
+	"type.int":         "{param} must be an integer",
+	"type.int32":       "{param} must be a 32-bit integer",
+	"type.int64":       "{param} must be a 64-bit integer",
+	"type.uint32":      "{param} must be a non-negative 32-bit integer",
+	"type.uint64":      "{param} must be a non-negative 64-bit integer",
+	"type.float":       "{param} must be a number",
+	"type.number":      "{param} must be a number",
+	"type.bool":        "{param} must be true or false",
+	"type.string":      "{param} must be a string",
+	"type.bytes":       "{param} must be bytes",
+	"type.ip":          "{param} must be an IP address",
+	"type.time":        "{param} must be a time",
+	"type.unix_time":   "{param} must be a unix time",
+	"type.duration":    "{param} must be a duration",
+	"type.location":    "{param} must be a time zone",
+	"type.json":        "{param} must be json",
+	"type.object":      "{param} must be an object",
+	"type.array":       "{param} must be an array",
+	"type.sized":       "{param} must be a string, an array or an object",
+	"type.comparable":  "{param} and {other} can't be compared",
+	"equal":            "{param} must be equal to {other}",
+	"less_than":        "{param} must be less than {other}",
+	"before":           "{param} must be before {other}",
+	"not_before":       "{param} must not be before {limit}",
+	"not_after":        "{param} must not be after {limit}",
+	"within_last":      "{param} must be within the last {limit}",
+	"future":           "{param} must be in the future",
+	"duration_between": "{param} must be between {min} and {max}",
+	"min":              "{param} must be at least {limit}",
+	"max":              "{param} must be at most {limit}",
+	"min_length":       "{param} must be at least {limit} characters long",
+	"max_length":       "{param} must be at most {limit} characters long",
+	"pattern":          "{param} must match {pattern}",
+	"format":           "{param} must be a valid {format}",
+	"enum":             "{param} must be one of {values}",
+	"max_bytes":        "{param} must not be larger than {limit} bytes",
+	"max_depth":        "{param} must not be nested deeper than {limit}",
+}
+
+var chineseCatalog = Catalog{
+	"required":         "{param} 為必填",
+	"unknown":          "不允許 {param}",
+	"unsafe":           "{param} 不可包含 {kind} 特殊字元 {meta}",
+	"type":             "{param} 必須是 {type}",
+	"type.int":         "{param} 必須是整數",
+	"type.int32":       "{param} 必須是 32 位元整數",
+	"type.int64":       "{param} 必須是 64 位元整數",
+	"type.uint32":      "{param} 必須是非負的 32 位元整數",
+	"type.uint64":      "{param} 必須是非負的 64 位元整數",
+	"type.float":       "{param} 必須是數字",
+	"type.number":      "{param} 必須是數字",
+	"type.bool":        "{param} 必須是 true 或 false",
+	"type.string":      "{param} 必須是字串",
+	"type.bytes":       "{param} 必須是位元組",
+	"type.ip":          "{param} 必須是 IP 位址",
+	"type.time":        "{param} 必須是時間",
+	"type.unix_time":   "{param} 必須是 Unix 時間",
+	"type.duration":    "{param} 必須是時間長度",
+	"type.location":    "{param} 必須是時區",
+	"type.json":        "{param} 必須是 JSON",
+	"type.object":      "{param} 必須是物件",
+	"type.array":       "{param} 必須是陣列",
+	"type.sized":       "{param} 必須是字串、陣列或物件",
+	"type.comparable":  "{param} 與 {other} 無法比較",
+	"equal":            "{param} 必須等於 {other}",
+	"less_than":        "{param} 必須小於 {other}",
+	"before":           "{param} 必須早於 {other}",
+	"not_before":       "{param} 不可早於 {limit}",
+	"not_after":        "{param} 不可晚於 {limit}",
+	"within_last":      "{param} 必須在最近 {limit} 內",
+	"future":           "{param} 必須是未來的時間",
+	"duration_between": "{param} 必須介於 {min} 與 {max} 之間",
+	"min":              "{param} 不可小於 {limit}",
+	"max":              "{param} 不可大於 {limit}",
+	"min_length":       "{param} 長度不可少於 {limit} 個字元",
+	"max_length":       "{param} 長度不可超過 {limit} 個字元",
+	"pattern":          "{param} 必須符合 {pattern}",
+	"format":           "{param} 必須是有效的 {format}",
+	"enum":             "{param} 必須是 {values} 其中之一",
+	"max_bytes":        "{param} 不可超過 {limit} 位元組",
+	"max_depth":        "{param} 的巢狀深度不可超過 {limit}",
+}
+
+// RegisterCatalog add the templates of locale, the templates override the registered ones with
+// the same codes, so the bundled "en" and "zh-TW" catalogs can be customized
+func RegisterCatalog(locale string, catalog Catalog) {
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+	merged := Catalog{}
+	for code, template := range catalogs[locale] {
+		merged[code] = template
+	}
+	for code, template := range catalog {
+		merged[code] = template
+	}
+	catalogs[locale] = merged
+}
+
+// Translate get the message of error in locale, the message of error is kept when the locale or
+// the code of error has no template
+func Translate(err error, locale string) string {
+	if localizable, ok := err.(localizable); ok {
+		if catalog, ok := catalogOf(locale); ok {
+			return localizable.localize(catalog).Error()
+		}
+	}
+	return err.Error()
+}
+
+// Locale set the locale of session, the errors of ValidateResult are in the messages of its catalog
+func (v *SanitizeType) Locale(locale string) *SanitizeType {
+	v.setLocale(locale)
+	return v
+}
+
+// Locale set the locale of session, the errors of ValidateResult are in the messages of its catalog
+func (v *CheckType) Locale(locale string) *CheckType {
+	v.setLocale(locale)
+	return v
+}
+
+// localizable is error whose message can be made from catalog
+type localizable interface {
+	localize(catalog Catalog) error
+}
+
+func catalogOf(locale string) (Catalog, bool) {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+	catalog, ok := catalogs[locale]
+	return catalog, ok
+}
+
+func (e basicError) localize(catalog Catalog) basicError {
+	template, ok := catalog[e.code]
+	if !ok {
+		return e
+	}
+	e.message = templateArgPattern.ReplaceAllStringFunc(template, func(name string) string {
+		if arg, ok := e.args[name[1:len(name)-1]]; ok {
+			return fmt.Sprint(arg)
+		}
+		return name
+	})
+	return e
+}
+
+func (v *validatorBase) setLocale(locale string) {
+	cache := v.content.GetCache()
+	cache[contextKey].(map[string]interface{})[localeKey] = locale
+}
+
+// localizeErrors make the messages of errors in the locale of session, the unknown locale is
+// reported as ConfigError
+func localizeErrors(payload Payload, errorList []error) []error {
+	locale, ok := payload.GetCache()[contextKey].(map[string]interface{})[localeKey].(string)
+	if !ok {
+		return errorList
+	}
+	catalog, ok := catalogOf(locale)
+	if !ok {
+		return append(errorList, newConfigError(fmt.Sprintf("locale %s has no catalog", locale)))
+	}
+	localized := make([]error, len(errorList))
+	for i, err := range errorList {
+		localized[i] = err
+		if localizable, ok := err.(localizable); ok {
+			localized[i] = localizable.localize(catalog)
+		}
+	}
+	return localized
+}
@@ -0,0 +1,114 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	RegisterCatalog("fr", Catalog{
+		"required": "{param} est obligatoire",
+		"type.int": "{param} doit être un entier, pas {value}",
+	})
+}
+
+func TestSanitizeLocale(t *testing.T) {
+	type testCase struct {
+		locale  string
+		errMsgs []string
+	}
+	cases := []testCase{
+		{
+			locale:  "en",
+			errMsgs: []string{"age must be an integer", "score is required"},
+		},
+		{
+			locale:  "zh-TW",
+			errMsgs: []string{"age 必須是整數", "score 為必填"},
+		},
+		{
+			locale:  "fr",
+			errMsgs: []string{"age doit être un entier, pas 18A", "score est obligatoire"},
+		},
+		{
+			locale:  "jp",
+			errMsgs: []string{"field age type is not int", "field score doesn't exist", "locale jp has no catalog"},
+		},
+	}
+	for _, tc := range cases {
+		payload := &message{msg: map[string]interface{}{"age": "18A"}}
+		player := testStruct{}
+		Sanitize(payload).Locale(tc.locale).Params("age").ToInt(&player)
+		Sanitize(payload).Params("score").ToInt(&player)
+		errs, _ := ValidateResult(payload)
+		errMsgs := []string{}
+		for _, err := range errs {
+			errMsgs = append(errMsgs, err.Error())
+		}
+		assert.Equal(t, tc.errMsgs, errMsgs)
+	}
+}
+
+func TestCheckLocale(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{"age": "18", "name": "'; drop table"}}
+	Check(payload).Locale("zh-TW").Params("age").IsInt()
+	Check(payload).Params("name").NoSQLMeta()
+	errs, _ := ValidateResult(payload)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "age 必須是整數", errs[0].Error())
+	assert.IsType(t, WrongTypeError{}, errs[0])
+	assert.Equal(t, "type.int", errs[0].(WrongTypeError).Code())
+	assert.IsType(t, UnsafeContentError{}, errs[1])
+	assert.Equal(t, "unsafe", errs[1].(UnsafeContentError).Code())
+
+	Check(payload).Params("age").IsInt()
+	errs, _ = ValidateResult(payload)
+	assert.Equal(t, "field age type is not int", errs[0].Error())
+}
+
+func TestTranslate(t *testing.T) {
+	type testCase struct {
+		err    error
+		locale string
+		msg    string
+	}
+	cases := []testCase{
+		{
+			err:    newInvalidValueError("field age is less than 18", "min", "param", "age", "limit", 18),
+			locale: "en",
+			msg:    "age must be at least 18",
+		},
+		{
+			err:    newInvalidValueError("field age is less than 18", "min", "param", "age", "limit", 18),
+			locale: "zh-TW",
+			msg:    "age 不可小於 18",
+		},
+		{
+			err:    newInvalidValueError("field age is less than 18", "min", "param", "age", "limit", 18),
+			locale: "jp",
+			msg:    "field age is less than 18",
+		},
+		{
+			err:    NewInvalidStructError("check in should be before check out", "CheckIn"),
+			locale: "en",
+			msg:    "check in should be before check out",
+		},
+		{
+			err:    errors.New("too many guests"),
+			locale: "en",
+			msg:    "too many guests",
+		},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.msg, Translate(tc.err, tc.locale))
+	}
+}
+
+func TestRegisterCatalog(t *testing.T) {
+	RegisterCatalog("en-test", Catalog{"required": "{param} is missing"})
+	RegisterCatalog("en-test", Catalog{"min": "{param} is below {limit}"})
+	assert.Equal(t, "age is missing", Translate(newNotExistError("field age doesn't exist", "required", "param", "age"), "en-test"))
+	assert.Equal(t, "age is below 3", Translate(newInvalidValueError("", "min", "param", "age", "limit", 3), "en-test"))
+}
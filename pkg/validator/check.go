@@ -1,7 +1,6 @@
 package validator
 
 import (
-	"fmt"
 	"reflect"
 )
 
@@ -43,7 +42,32 @@ func Check(payload Payload) *CheckType {
 
 // Params tag the param that will be sanitized
 func (v *CheckType) Params(param string) *CheckType {
-	v.param = param
+	v.setParam(param)
+	return v
+}
+
+// Strict report the params that are not asked by any rule or target struct,
+// the payload should implement ParamLister for it
+func (v *CheckType) Strict() *CheckType {
+	v.setStrict()
+	return v
+}
+
+// FailFast stop validating at the first error of the session
+func (v *CheckType) FailFast() *CheckType {
+	v.setFailFast()
+	return v
+}
+
+// WithClock set the clock of session, time-relative rules use it as the reference time
+func (v *CheckType) WithClock(clock Clock) *CheckType {
+	v.setClock(clock)
+	return v
+}
+
+// Bail skip the following rules of param once one of them fails
+func (v *CheckType) Bail() *CheckType {
+	v.bail = true
 	return v
 }
 
@@ -104,41 +128,57 @@ func (v *CheckType) isType(dataType int) *CheckType {
 		var err error
 		switch dataType {
 		case intType:
-			if reflect.TypeOf(val).Kind() != reflect.Int {
-				err = newWrongTypeError(fmt.Sprintf("field %s type is not int", v.param))
+			if kindOf(val) != reflect.Int {
+				err = newTypeError(v.getParam(), "int")
 			}
 		case int32Type:
-			if reflect.TypeOf(val).Kind() != reflect.Int32 {
-				err = newWrongTypeError(fmt.Sprintf("field %s type is not int32", v.param))
+			if kindOf(val) != reflect.Int32 {
+				err = newTypeError(v.getParam(), "int32")
 			}
 		case int64Type:
-			if reflect.TypeOf(val).Kind() != reflect.Int64 {
-				err = newWrongTypeError(fmt.Sprintf("field %s type is not int64", v.param))
+			if kindOf(val) != reflect.Int64 {
+				err = newTypeError(v.getParam(), "int64")
 			}
 		case uint32Type:
-			if reflect.TypeOf(val).Kind() != reflect.Uint32 {
-				err = newWrongTypeError(fmt.Sprintf("field %s type is not uint32", v.param))
+			if kindOf(val) != reflect.Uint32 {
+				err = newTypeError(v.getParam(), "uint32")
 			}
 		case uint64Type:
-			if reflect.TypeOf(val).Kind() != reflect.Uint64 {
-				err = newWrongTypeError(fmt.Sprintf("field %s type is not uint64", v.param))
+			if kindOf(val) != reflect.Uint64 {
+				err = newTypeError(v.getParam(), "uint64")
 			}
 		case float64Type:
-			if reflect.TypeOf(val).Kind() != reflect.Float64 {
-				err = newWrongTypeError(fmt.Sprintf("field %s type is not float64", v.param))
+			if kindOf(val) != reflect.Float64 {
+				err = newTypeError(v.getParam(), "float64")
 			}
 		case boolType:
-			if reflect.TypeOf(val).Kind() != reflect.Bool {
-				err = newWrongTypeError(fmt.Sprintf("field %s type is not bool", v.param))
+			if kindOf(val) != reflect.Bool {
+				err = newTypeError(v.getParam(), "bool")
 			}
 		case stringType:
-			if reflect.TypeOf(val).Kind() != reflect.String {
-				err = newWrongTypeError(fmt.Sprintf("field %s type is not string", v.param))
+			if kindOf(val) != reflect.String {
+				err = newTypeError(v.getParam(), "string")
 			}
 		case bytesType:
-			if reflect.TypeOf(val).Kind() != reflect.Slice ||
+			if kindOf(val) != reflect.Slice ||
 				reflect.TypeOf(val).Elem().Kind() != reflect.Uint8 {
-				err = newWrongTypeError(fmt.Sprintf("field %s type is not bytes", v.param))
+				err = newTypeError(v.getParam(), "bytes")
+			}
+		case ipType:
+			if reflect.TypeOf(val) != ipReflectType {
+				err = newTypeError(v.getParam(), "ip")
+			}
+		case timeType:
+			if reflect.TypeOf(val) != timeReflectType {
+				err = newTypeError(v.getParam(), "time")
+			}
+		case durationType:
+			if reflect.TypeOf(val) != durationReflectType {
+				err = newTypeError(v.getParam(), "duration")
+			}
+		case locationType:
+			if reflect.TypeOf(val) != locationReflectType {
+				err = newTypeError(v.getParam(), "time zone")
 			}
 		}
 		v.handleErrors(err)
@@ -151,5 +191,13 @@ func (v *CheckType) handleAbsence() (interface{}, bool) {
 }
 
 func (v *CheckType) getAbsenceError() error {
-	return newNotExistError(v.param + " don't exist!")
+	return newRequiredError(v.getParam())
+}
+
+// kindOf get the kind of value, it is reflect.Invalid for nil like json null
+func kindOf(val interface{}) reflect.Kind {
+	if val == nil {
+		return reflect.Invalid
+	}
+	return reflect.TypeOf(val).Kind()
 }
@@ -0,0 +1,214 @@
+package validator
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// EqualTo check param is equal to other param
+func (v *CheckType) EqualTo(other string) *CheckType {
+	val, otherVal, ok := v.pair(other)
+	if ok && !reflect.DeepEqual(val, otherVal) {
+		v.handleErrors(newInvalidValueError(fmt.Sprintf("field %s is not equal to %s", v.getParam(), v.prefix+other), "equal", "param", v.getParam(), "other", v.prefix+other))
+	}
+	return v
+}
+
+// LessThan check param is less than other param, both of them should be numbers or times
+func (v *CheckType) LessThan(other string) *CheckType {
+	val, otherVal, ok := v.pair(other)
+	if ok {
+		v.handleErrors(lessThan(v.getParam(), v.prefix+other, val, otherVal))
+	}
+	return v
+}
+
+// pair get the values of param and other param, the absent other param is reported like the
+// absent param, so EqualTo("password_confirm") fails when the confirmation is missing. The other
+// param is asked by the rule, so it isn't unknown in strict mode
+func (v *CheckType) pair(other string) (interface{}, interface{}, bool) {
+	v.cover(other)
+	val, exist := v.handleAbsence()
+	if !exist || v.isBroken(v.getParam()) || v.isBroken(v.prefix+other) {
+		return nil, nil, false
+	}
+	otherVal, exist := v.content.GetParam(v.prefix + other)
+	if !exist {
+		v.handleErrors(newRequiredError(v.prefix + other))
+		v.markInvalid(v.prefix + other)
+	}
+	return val, otherVal, exist
+}
+
+// EqualTo check sanitized field is equal to the field of other param
+func (v *SanitizeType) EqualTo(other string) *SanitizeType {
+	val, otherVal, ok := v.pair(other)
+	if ok && !reflect.DeepEqual(val, otherVal) {
+		v.handleErrors(newInvalidValueError(fmt.Sprintf("field %s is not equal to %s", v.getParam(), v.prefix+other), "equal", "param", v.getParam(), "other", v.prefix+other))
+	}
+	return v
+}
+
+// LessThan check sanitized field is less than the field of other param
+func (v *SanitizeType) LessThan(other string) *SanitizeType {
+	val, otherVal, ok := v.pair(other)
+	if ok {
+		v.handleErrors(lessThan(v.getParam(), v.prefix+other, val, otherVal))
+	}
+	return v
+}
+
+// Before check sanitized time is before the time of other param
+func (v *SanitizeType) Before(other string) *SanitizeType {
+	val, otherVal, ok := v.pair(other)
+	if ok {
+		v.handleErrors(before(v.getParam(), v.prefix+other, val, otherVal))
+	}
+	return v
+}
+
+// After check sanitized time is after the time of other param
+func (v *SanitizeType) After(other string) *SanitizeType {
+	val, otherVal, ok := v.pair(other)
+	if ok {
+		v.handleErrors(before(v.prefix+other, v.getParam(), otherVal, val))
+	}
+	return v
+}
+
+// pair get the sanitized fields of param and other param, both of them should be
+// sanitized into the same struct before comparing. The other param which is absent or
+// broken is skipped, and the other param which isn't sanitized yet is ConfigError
+func (v *SanitizeType) pair(other string) (interface{}, interface{}, bool) {
+	v.cover(other)
+	if v.out == nil || v.skip() || v.isBroken(v.getParam()) || v.isBroken(v.prefix+other) || !v.isSanitized(v.getParam()) {
+		return nil, nil, false
+	}
+	if _, exist := v.content.GetParam(v.prefix + other); !exist {
+		return nil, nil, false
+	}
+	if !v.isSanitized(v.prefix + other) {
+		v.handleErrors(newConfigError(fmt.Sprintf("field %s should be sanitized before comparing with %s", v.prefix+other, v.getParam()), "param", v.getParam(), "other", v.prefix+other))
+		return nil, nil, false
+	}
+	val, ok := fieldValue(v.out, v.param)
+	if !ok {
+		return nil, nil, false
+	}
+	otherVal, ok := fieldValue(v.out, other)
+	return val, otherVal, ok
+}
+
+func fieldValue(out interface{}, param string) (interface{}, bool) {
+	field, _, err := fieldByTag(out, param)
+	if mapValue := reflect.Indirect(reflect.ValueOf(out)); isMapTarget(mapValue) {
+		field, err = mapValue.MapIndex(reflect.ValueOf(param).Convert(mapValue.Type().Key())), nil
+		if !field.IsValid() {
+			return nil, false
+		}
+		if field.Kind() == reflect.Interface {
+			field = field.Elem()
+		}
+	}
+	if err != nil {
+		return nil, false
+	}
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil, false
+		}
+		field = field.Elem()
+	}
+	return field.Interface(), true
+}
+
+func lessThan(param, other string, val, otherVal interface{}) error {
+	result, ok := compareValues(val, otherVal)
+	if !ok {
+		return newWrongTypeError(fmt.Sprintf("field %s and %s are not comparable", param, other), "type.comparable", "param", param, "other", other)
+	}
+	if result >= 0 {
+		return newInvalidValueError(fmt.Sprintf("field %s is not less than %s", param, other), "less_than", "param", param, "other", other)
+	}
+	return nil
+}
+
+func before(param, other string, val, otherVal interface{}) error {
+	valTime, ok := val.(time.Time)
+	otherTime, otherOk := otherVal.(time.Time)
+	if !ok || !otherOk {
+		return newWrongTypeError(fmt.Sprintf("field %s and %s are not time", param, other), "type.time", "param", param, "other", other)
+	}
+	if !valTime.Before(otherTime) {
+		return newInvalidValueError(fmt.Sprintf("field %s is not before %s", param, other), "before", "param", param, "other", other)
+	}
+	return nil
+}
+
+// compareValues compare two numbers or two times, it returns -1, 0 or 1
+func compareValues(val, otherVal interface{}) (int, bool) {
+	if valTime, ok := val.(time.Time); ok {
+		otherTime, ok := otherVal.(time.Time)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case valTime.Before(otherTime):
+			return -1, true
+		case valTime.After(otherTime):
+			return 1, true
+		}
+		return 0, true
+	}
+	// integers are compared exactly, the float64 of them loses precision above 2^53
+	if valInt, ok := toInteger(val); ok {
+		if otherInt, ok := toInteger(otherVal); ok {
+			return valInt.Cmp(otherInt), true
+		}
+	}
+	valFloat, ok := toFloat(val)
+	otherFloat, otherOk := toFloat(otherVal)
+	if !ok || !otherOk {
+		return 0, false
+	}
+	switch {
+	case valFloat < otherFloat:
+		return -1, true
+	case valFloat > otherFloat:
+		return 1, true
+	}
+	return 0, true
+}
+
+// toInteger get the integer of int, uint or integer string, it fails for float
+func toInteger(val interface{}) (*big.Int, bool) {
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint()), true
+	case reflect.String:
+		return new(big.Int).SetString(rv.String(), 10)
+	}
+	return nil, false
+}
+
+func toFloat(val interface{}) (float64, bool) {
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.String:
+		f, err := strconv.ParseFloat(rv.String(), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
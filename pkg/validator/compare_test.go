@@ -0,0 +1,233 @@
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckEqualTo(t *testing.T) {
+	type testCase struct {
+		dataReq         *message
+		wantAbsence     int
+		wantFormatError int
+	}
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"password":         "secret",
+				"password_confirm": "secret",
+			}},
+			wantAbsence:     0,
+			wantFormatError: 0,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"password":         "secret",
+				"password_confirm": "secreT",
+			}},
+			wantAbsence:     0,
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"password": "secret",
+			}},
+			wantAbsence:     0,
+			wantFormatError: 1,
+		},
+	}
+	for _, tc := range cases {
+		Check(tc.dataReq).Params("password").EqualTo("password_confirm")
+		formatErrs, absence := ValidateResult(tc.dataReq)
+		assert.Equal(t, tc.wantFormatError, len(formatErrs))
+		assert.Equal(t, tc.wantAbsence, len(absence))
+		if len(formatErrs) > 0 {
+			_, invalid := formatErrs[0].(InvalidValueError)
+			_, notExist := formatErrs[0].(NotExistError)
+			assert.True(t, invalid || notExist)
+		}
+	}
+}
+
+func TestCheckEqualToAbsentOther(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"password": "secret",
+	}}
+	Check(payload).Params("password").EqualTo("password_confirm")
+	formatErrs, _ := ValidateResult(payload)
+	assert.Equal(t, 1, len(formatErrs))
+	assert.EqualError(t, formatErrs[0], "field password_confirm doesn't exist")
+}
+
+func TestCheckLessThan(t *testing.T) {
+	type testCase struct {
+		dataReq         *message
+		wantFormatError int
+	}
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"start": 1,
+				"end":   2,
+			}},
+			wantFormatError: 0,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"start": "3",
+				"end":   2.5,
+			}},
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"start": "3A",
+				"end":   2,
+			}},
+			wantFormatError: 1,
+		},
+	}
+	for _, tc := range cases {
+		Check(tc.dataReq).Params("start").LessThan("end")
+		formatErrs, _ := ValidateResult(tc.dataReq)
+		assert.Equal(t, tc.wantFormatError, len(formatErrs))
+	}
+}
+
+func TestCheckLessThanSkipBroken(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"start": "1",
+		"end":   2,
+	}}
+	Check(payload).Params("start").IsInt().LessThan("end")
+	formatErrs, _ := ValidateResult(payload)
+	assert.Equal(t, 1, len(formatErrs))
+	_, ok := formatErrs[0].(WrongTypeError)
+	assert.True(t, ok)
+}
+
+func TestSanitizeBefore(t *testing.T) {
+	type testCase struct {
+		dataReq         *message
+		wantFormatError int
+	}
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"startTime": "2020-11-06 16:19:23",
+				"endTime":   "2020-11-07 16:19:23",
+			}},
+			wantFormatError: 0,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"startTime": "2020-11-08 16:19:23",
+				"endTime":   "2020-11-07 16:19:23",
+			}},
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"startTime": "2020-11-08 16:19:23",
+				"endTime":   "2020-11-07",
+			}},
+			wantFormatError: 1,
+		},
+	}
+	for _, tc := range cases {
+		actual := testStruct{}
+		Sanitize(tc.dataReq).Params("endTime").TimeFormat("2006-01-02 15:04:05").ToTime(&actual)
+		Sanitize(tc.dataReq).Params("startTime").TimeFormat("2006-01-02 15:04:05").ToTime(&actual).Before("endTime")
+		formatErrs, _ := ValidateResult(tc.dataReq)
+		assert.Equal(t, tc.wantFormatError, len(formatErrs))
+	}
+}
+
+func TestSanitizeBeforeUnsanitized(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"startTime": "2020-11-06 16:19:23",
+		"endTime":   "2020-11-07 16:19:23",
+	}}
+	actual := testStruct{}
+	Sanitize(payload).Params("startTime").TimeFormat("2006-01-02 15:04:05").ToTime(&actual).Before("endTime")
+	formatErrs, _ := ValidateResult(payload)
+	assert.Equal(t, 1, len(formatErrs))
+	assert.IsType(t, ConfigError{}, formatErrs[0])
+	assert.EqualError(t, formatErrs[0], "field endTime should be sanitized before comparing with startTime")
+
+	Sanitize(payload).Params("endTime").TimeFormat("2006-01-02 15:04:05").ToTime(&actual)
+	Sanitize(payload).Params("startTime").TimeFormat("2006-01-02 15:04:05").ToTime(&actual).Before("endTime")
+	formatErrs, _ = ValidateResult(payload)
+	assert.Equal(t, 0, len(formatErrs))
+}
+
+func TestSanitizeAfter(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"startTime": "2020-11-06 16:19:23",
+		"endTime":   "2020-11-07 16:19:23",
+	}}
+	actual := testStruct{}
+	Sanitize(payload).Params("startTime").TimeFormat("2006-01-02 15:04:05").ToTime(&actual)
+	Sanitize(payload).Params("endTime").TimeFormat("2006-01-02 15:04:05").ToTime(&actual).After("startTime")
+	formatErrs, _ := ValidateResult(payload)
+	assert.Equal(t, 0, len(formatErrs))
+
+	Sanitize(payload).Params("startTime").TimeFormat("2006-01-02 15:04:05").ToTime(&actual).After("endTime")
+	formatErrs, _ = ValidateResult(payload)
+	assert.Equal(t, 1, len(formatErrs))
+}
+
+func TestSanitizeLessThan(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"age":   "18",
+		"score": "60",
+	}}
+	actual := testStruct{}
+	Sanitize(payload).Params("score").ToInt(&actual)
+	Sanitize(payload).Params("age").ToInt(&actual).LessThan("score").EqualTo("score")
+	formatErrs, _ := ValidateResult(payload)
+	assert.Equal(t, 1, len(formatErrs))
+	_, ok := formatErrs[0].(InvalidValueError)
+	assert.True(t, ok)
+}
+
+func TestSanitizeLessThanLargeIntegers(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"start": "9007199254740992",
+		"end":   "9007199254740993",
+	}}
+	values := map[string]interface{}{}
+	Sanitize(payload).Params("end").ToInt(values)
+	Sanitize(payload).Params("start").ToInt(values).LessThan("end")
+	formatErrs, _ := ValidateResult(payload)
+	assert.Equal(t, 0, len(formatErrs))
+
+	payload = &message{msg: map[string]interface{}{
+		"start": uint64(18446744073709551615),
+		"end":   int64(-1),
+	}}
+	Check(payload).Params("end").LessThan("start")
+	Check(payload).Params("start").LessThan("end")
+	formatErrs, _ = ValidateResult(payload)
+	assert.Equal(t, 1, len(formatErrs))
+	assert.EqualError(t, formatErrs[0], "field start is not less than end")
+}
+
+func TestSanitizeCompareNested(t *testing.T) {
+	nested := &objectPayload{prefix: "booking.", values: map[string]json.RawMessage{
+		"start": json.RawMessage(`1`),
+		"end":   json.RawMessage(`"x"`),
+	}}
+	values := map[string]interface{}{}
+	end := Sanitize(nested)
+	end.prefix = nested.prefix
+	end.Params("end").ToInt(values)
+	start := Sanitize(nested)
+	start.prefix = nested.prefix
+	start.Params("start").ToInt(values).LessThan("end")
+	formatErrs, _ := ValidateResult(nested)
+	assert.Equal(t, 1, len(formatErrs))
+	assert.EqualError(t, formatErrs[0], "field booking.end type is not int")
+}
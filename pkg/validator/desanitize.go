@@ -0,0 +1,149 @@
+package validator
+
+import (
+	"encoding/json"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Desanitize format the tagged fields of struct into messages keyed by param, it is the inverse of
+// sanitizing. Times are formatted by the first of layouts, time.RFC3339 is used when no layout is
+// provided, and objects are formatted as json. The nil and unexported fields are skipped
+//
+//	values := validator.Desanitize(&player)
+func Desanitize(in interface{}, layouts ...string) map[string]string {
+	messages := map[string]string{}
+	target := reflect.Indirect(reflect.ValueOf(in))
+	if target.Kind() != reflect.Struct {
+		return messages
+	}
+	layout := time.RFC3339
+	if len(layouts) > 0 {
+		layout = layouts[0]
+	}
+	for param, field := range desanitizeFields(target) {
+		messages[param], _ = formatField(field.value, field.options, layout)
+	}
+	return messages
+}
+
+// ToValues format the tagged fields of struct into url.Values like Desanitize
+func ToValues(in interface{}, layouts ...string) url.Values {
+	values := url.Values{}
+	for param, message := range Desanitize(in, layouts...) {
+		values.Set(param, message)
+	}
+	return values
+}
+
+// desanitizeField is the tagged field with value
+type desanitizeField struct {
+	value   reflect.Value
+	options []string
+}
+
+// desanitizeFields get the exported tagged fields which aren't nil
+func desanitizeFields(target reflect.Value) map[string]desanitizeField {
+	fields := map[string]desanitizeField{}
+	info := structInfoOf(target.Type())
+	for _, param := range info.tags {
+		field := info.byParam[param]
+		if !field.exported {
+			continue
+		}
+		value := target.FieldByIndex(field.index)
+		if value.Kind() == reflect.Ptr && value.Type() != locationReflectType {
+			if value.IsNil() {
+				continue
+			}
+			value = value.Elem()
+		}
+		if (value.Kind() == reflect.Map || value.Kind() == reflect.Slice || value.Kind() == reflect.Ptr) && value.IsNil() {
+			continue
+		}
+		fields[param] = desanitizeField{value: value, options: field.options}
+	}
+	return fields
+}
+
+// formatField format the value by the inverse of its conversion, it reports whether the message is json
+func formatField(value reflect.Value, options []string, layout string) (string, bool) {
+	switch value.Type() {
+	case timeReflectType:
+		timeVal := value.Interface().(time.Time)
+		if _, utc := tagOption(options, "utc"); utc {
+			timeVal = timeVal.UTC()
+		} else if zone, ok := tagOption(options, "tz"); ok {
+			if loc, err := time.LoadLocation(zone); err == nil {
+				timeVal = timeVal.In(loc)
+			}
+		}
+		return timeVal.Format(layout), false
+	case durationReflectType:
+		return value.Interface().(time.Duration).String(), false
+	case ipReflectType:
+		return value.Interface().(net.IP).String(), false
+	case locationReflectType:
+		return value.Interface().(*time.Location).String(), false
+	}
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), false
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), false
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), false
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), false
+	case reflect.String:
+		return value.String(), false
+	case reflect.Struct:
+		if isTaggedStruct(value.Type()) {
+			return formatNested(value, layout), true
+		}
+	}
+	data, _ := json.Marshal(value.Interface())
+	return string(data), true
+}
+
+// formatNested format the tagged struct into json object keyed by param, the values are json
+// strings except objects, so sanitizeNested get the same messages. The fields without vld tags
+// are formatted by json, as sanitizeNested decodes them by json
+func formatNested(value reflect.Value, layout string) string {
+	object := untaggedJSON(value)
+	for param, field := range desanitizeFields(value) {
+		message, isJSON := formatField(field.value, field.options, layout)
+		if isJSON {
+			object[param] = json.RawMessage(message)
+			continue
+		}
+		object[param], _ = json.Marshal(message)
+	}
+	data, _ := json.Marshal(object)
+	return string(data)
+}
+
+// untaggedJSON get the json of the struct fields without vld tags keyed by their json names
+func untaggedJSON(value reflect.Value) map[string]json.RawMessage {
+	object := map[string]json.RawMessage{}
+	data, err := json.Marshal(value.Interface())
+	if err != nil || json.Unmarshal(data, &object) != nil {
+		return map[string]json.RawMessage{}
+	}
+	for _, field := range structInfoOf(value.Type()).fields {
+		delete(object, jsonName(value.Type().FieldByIndex(field.index)))
+	}
+	return object
+}
+
+// jsonName get the key of field in json, like the name of json tag or the field name
+func jsonName(field reflect.StructField) string {
+	if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" {
+		return name
+	}
+	return field.Name
+}
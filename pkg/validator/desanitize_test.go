@@ -0,0 +1,115 @@
+package validator
+
+import (
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type desanitizeStruct struct {
+	Name    string            `vld:"name"`
+	Age     *int              `vld:"age"`
+	HP      *int              `vld:"hp"`
+	Weight  float64           `vld:"w"`
+	IsAlive bool              `vld:"alive"`
+	IP      net.IP            `vld:"ip"`
+	Start   time.Time         `vld:"start,tz=Asia/Taipei"`
+	Timeout time.Duration     `vld:"timeout"`
+	Zone    *time.Location    `vld:"zone"`
+	Hand    map[string]string `vld:"hand"`
+	Body    body              `vld:"body"`
+	hidden  string            `vld:"hidden"`
+}
+
+func TestDesanitize(t *testing.T) {
+	taipei, _ := time.LoadLocation("Asia/Taipei")
+	age := 18
+	in := desanitizeStruct{
+		Name:    "ken",
+		Age:     &age,
+		Weight:  60.5,
+		IsAlive: true,
+		IP:      net.ParseIP("127.0.0.1"),
+		Start:   time.Date(2020, 11, 6, 0, 0, 0, 0, time.UTC),
+		Timeout: 90 * time.Second,
+		Zone:    taipei,
+		Hand:    map[string]string{"finger": "5"},
+		Body: body{Foot: foot{
+			Size:    42,
+			Bought:  time.Date(2020, 11, 6, 16, 0, 0, 0, taipei),
+			BigToe:  &toe{Length: 1.5},
+			Comment: "nice",
+			Brand:   "acme",
+		}},
+		hidden: "x",
+	}
+	messages := Desanitize(&in)
+	assert.Equal(t, map[string]string{
+		"name":    "ken",
+		"age":     "18",
+		"w":       "60.5",
+		"alive":   "true",
+		"ip":      "127.0.0.1",
+		"start":   "2020-11-06T08:00:00+08:00",
+		"timeout": "1m30s",
+		"zone":    "Asia/Taipei",
+		"hand":    `{"finger":"5"}`,
+		"body":    `{"foot":{"Comment":"nice","bigToe":{"length":"1.5"},"bought":"2020-11-06T16:00:00+08:00","brand_name":"acme","color":"","size":"42"}}`,
+	}, messages)
+
+	payload := &message{msg: map[string]interface{}{}}
+	for param, msg := range messages {
+		payload.msg[param] = msg
+	}
+	out := desanitizeStruct{}
+	Sanitize(payload).Params("name").ToString(&out)
+	Sanitize(payload).Params("age").ToInt(&out)
+	Sanitize(payload).Params("w").ToFloat64(&out)
+	Sanitize(payload).Params("alive").ToBool(&out)
+	Sanitize(payload).Params("ip").ToIP(&out)
+	Sanitize(payload).Params("start").ToTime(&out)
+	Sanitize(payload).Params("timeout").ToDuration(&out)
+	Sanitize(payload).Params("zone").ToLocation(&out)
+	Sanitize(payload).Params("hand").ToObject(&out)
+	Sanitize(payload).Params("body").ToStruct(&out)
+	errs, _ := ValidateResult(payload)
+	assert.Equal(t, 0, len(errs))
+	assert.Equal(t, 18, *out.Age)
+	assert.True(t, in.Start.Equal(out.Start))
+	assert.True(t, in.Body.Foot.Bought.Equal(out.Body.Foot.Bought))
+	assert.Equal(t, in.Body.Foot.BigToe, out.Body.Foot.BigToe)
+	assert.Equal(t, in.Body.Foot.Comment, out.Body.Foot.Comment)
+	assert.Equal(t, in.Body.Foot.Brand, out.Body.Foot.Brand)
+	assert.Equal(t, in.Hand, out.Hand)
+	assert.Equal(t, in.IP, out.IP)
+	assert.Equal(t, in.Name, out.Name)
+	assert.Equal(t, in.Weight, out.Weight)
+	assert.Equal(t, in.IsAlive, out.IsAlive)
+	assert.Equal(t, in.Timeout, out.Timeout)
+	assert.Equal(t, in.Zone.String(), out.Zone.String())
+
+	assert.Equal(t, map[string]string{}, Desanitize(3))
+}
+
+func TestDesanitizeFloat(t *testing.T) {
+	type ratio struct {
+		Small float32 `vld:"small"`
+		Large float64 `vld:"large"`
+	}
+	assert.Equal(t, map[string]string{"small": "0.1", "large": "0.1"}, Desanitize(ratio{Small: 0.1, Large: 0.1}))
+}
+
+func TestToValues(t *testing.T) {
+	in := zoneStruct{
+		Start: time.Date(2020, 11, 6, 8, 0, 0, 0, time.UTC),
+		End:   time.Date(2020, 11, 6, 8, 0, 0, 0, time.UTC),
+	}
+	assert.Equal(t, url.Values{
+		"start": {"2020-11-06 16:00:00"},
+		"end":   {"2020-11-06 08:00:00"},
+		"bad":   {"0001-01-01 00:00:00"},
+	}, ToValues(in, "2006-01-02 15:04:05"))
+}
@@ -1,35 +1,222 @@
 package validator
 
+import "fmt"
+
 type basicError struct {
 	message string
+	// code is the key of message catalog, and args are the values of its template
+	code string
+	args map[string]interface{}
 }
 
 func (e basicError) Error() string {
 	return e.message
 }
 
+// Code get the error code, it is the key of message catalog like "required" and "type.int"
+func (e basicError) Code() string {
+	return e.code
+}
+
+// Field get the param of field causing the error, like "age" and "leg.number", it is empty
+// when the error isn't caused by a field
+func (e basicError) Field() string {
+	param, _ := e.args["param"].(string)
+	return param
+}
+
+// newBasicError build error with code, the args are pairs of name and value like "param", "age"
+func newBasicError(msg string, code string, args []interface{}) basicError {
+	argMap := make(map[string]interface{}, len(args)/2)
+	for i := 0; i+1 < len(args); i += 2 {
+		argMap[fmt.Sprint(args[i])] = args[i+1]
+	}
+	return basicError{message: msg, code: code, args: argMap}
+}
+
 // WrongTypeError means message format is wrong
 type WrongTypeError struct {
 	basicError
+	// Layouts are the time layouts tried when the message is not time
+	Layouts []string
 }
 
-func newWrongTypeError(msg string) WrongTypeError {
+func newWrongTypeError(msg string, code string, args ...interface{}) WrongTypeError {
 	return WrongTypeError{
-		basicError: basicError{
-			message: msg,
-		},
+		basicError: newBasicError(msg, code, args),
 	}
 }
 
+// typeCodes are the error codes of type names
+var typeCodes = map[string]string{
+	"int":       "type.int",
+	"int32":     "type.int32",
+	"int64":     "type.int64",
+	"uint32":    "type.uint32",
+	"uint64":    "type.uint64",
+	"float64":   "type.float",
+	"bool":      "type.bool",
+	"string":    "type.string",
+	"bytes":     "type.bytes",
+	"ip":        "type.ip",
+	"time":      "type.time",
+	"unix time": "type.unix_time",
+	"duration":  "type.duration",
+	"time zone": "type.location",
+	"json":      "type.json",
+	"object":    "type.object",
+	"array":     "type.array",
+	"number":    "type.number",
+}
+
+// newTypeError build the error of param whose type isn't typeName, like "field age type is not int",
+// the args are the extra pairs of template like "value", "18A"
+func newTypeError(param string, typeName string, args ...interface{}) WrongTypeError {
+	return newWrongTypeError(fmt.Sprintf("field %s type is not %s", param, typeName), typeCodes[typeName],
+		append([]interface{}{"param", param}, args...)...)
+}
+
+func (e WrongTypeError) localize(catalog Catalog) error {
+	e.basicError = e.basicError.localize(catalog)
+	return e
+}
+
 // NotExistError means parameter do not exist
 type NotExistError struct {
 	basicError
 }
 
-func newNotExistError(msg string) NotExistError {
+func newNotExistError(msg string, code string, args ...interface{}) NotExistError {
 	return NotExistError{
+		basicError: newBasicError(msg, code, args),
+	}
+}
+
+// newRequiredError build the error of absent param, like "field age doesn't exist"
+func newRequiredError(param string) NotExistError {
+	return newNotExistError(fmt.Sprintf("field %s doesn't exist", param), "required", "param", param)
+}
+
+func (e NotExistError) localize(catalog Catalog) error {
+	e.basicError = e.basicError.localize(catalog)
+	return e
+}
+
+// InvalidValueError means parameter value breaks a rule
+type InvalidValueError struct {
+	basicError
+}
+
+func newInvalidValueError(msg string, code string, args ...interface{}) InvalidValueError {
+	return InvalidValueError{
+		basicError: newBasicError(msg, code, args),
+	}
+}
+
+func (e InvalidValueError) localize(catalog Catalog) error {
+	e.basicError = e.basicError.localize(catalog)
+	return e
+}
+
+// UnknownParamError means parameter is not asked by any rule in strict mode
+type UnknownParamError struct {
+	basicError
+}
+
+func newUnknownParamError(msg string, code string, args ...interface{}) UnknownParamError {
+	return UnknownParamError{
+		basicError: newBasicError(msg, code, args),
+	}
+}
+
+func (e UnknownParamError) localize(catalog Catalog) error {
+	e.basicError = e.basicError.localize(catalog)
+	return e
+}
+
+// UnsafeContentError means parameter carries suspicious content, like meta characters of sql or shell
+type UnsafeContentError struct {
+	basicError
+}
+
+func newUnsafeContentError(msg string, code string, args ...interface{}) UnsafeContentError {
+	return UnsafeContentError{
+		basicError: newBasicError(msg, code, args),
+	}
+}
+
+func (e UnsafeContentError) localize(catalog Catalog) error {
+	e.basicError = e.basicError.localize(catalog)
+	return e
+}
+
+// ConfigError means validator is used in wrong way, like the target has no field tagged with param
+type ConfigError struct {
+	basicError
+}
+
+// newConfigError build ConfigError, the args are pairs of name and value like "param", "age",
+// so the error tells the param it is about
+func newConfigError(msg string, args ...interface{}) ConfigError {
+	return ConfigError{
+		basicError: newBasicError(msg, "", args),
+	}
+}
+
+// SchemaError means schema document is invalid
+type SchemaError struct {
+	basicError
+	// Line is the line of schema document where the error is, it is 0 when the line is unknown
+	Line int
+}
+
+func newSchemaError(line int, msg string) SchemaError {
+	if line > 0 {
+		msg = fmt.Sprintf("line %d: %s", line, msg)
+	}
+	return SchemaError{
+		basicError: basicError{
+			message: msg,
+		},
+		Line: line,
+	}
+}
+
+// InvalidStructError means struct breaks its struct-level rule, Params are the params of the fields
+// breaking the rule, like "leg.number"
+type InvalidStructError struct {
+	basicError
+	Params []string
+	err    error
+}
+
+// NewInvalidStructError return the error of struct-level rule, the fields are go field names or
+// params of the fields breaking the rule
+func NewInvalidStructError(msg string, fields ...string) InvalidStructError {
+	return InvalidStructError{
 		basicError: basicError{
 			message: msg,
+			code:    "struct",
 		},
+		Params: fields,
 	}
 }
+
+func (e InvalidStructError) localize(catalog Catalog) error {
+	e.basicError = e.basicError.localize(catalog)
+	return e
+}
+
+// Field get the param of the first field breaking the rule, it is empty when the rule doesn't
+// tell the fields
+func (e InvalidStructError) Field() string {
+	if len(e.Params) == 0 {
+		return ""
+	}
+	return e.Params[0]
+}
+
+// Unwrap get the error returned by struct-level rule when it isn't InvalidStructError
+func (e InvalidStructError) Unwrap() error {
+	return e.err
+}
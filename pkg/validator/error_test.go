@@ -0,0 +1,101 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fieldError interface {
+	error
+	Field() string
+}
+
+func TestErrorField(t *testing.T) {
+	type testCase struct {
+		validate func(payload *listedMessage)
+		errMsgs  []string
+		fields   []string
+	}
+	cases := []testCase{
+		{
+			validate: func(payload *listedMessage) {
+				Check(payload).Params("age").IsInt()
+			},
+			errMsgs: []string{"field age type is not int"},
+			fields:  []string{"age"},
+		},
+		{
+			validate: func(payload *listedMessage) {
+				Sanitize(payload).Params("age").ToInt(&testStruct{})
+			},
+			errMsgs: []string{"field age type is not int"},
+			fields:  []string{"age"},
+		},
+		{
+			validate: func(payload *listedMessage) {
+				Sanitize(payload).Params("score").ToInt(&testStruct{})
+				Check(payload).Params("w").IsFloat()
+			},
+			errMsgs: []string{"field score doesn't exist", "field w doesn't exist"},
+			fields:  []string{"score", "w"},
+		},
+		{
+			validate: func(payload *listedMessage) {
+				Sanitize(payload).Params("stay").ToStruct(&trip{})
+			},
+			errMsgs: []string{"field stay.guests type is not int"},
+			fields:  []string{"stay.guests"},
+		},
+		{
+			validate: func(payload *listedMessage) {
+				Sanitize(payload).Params("start").ToTime(&testStruct{})
+			},
+			errMsgs: []string{"target *validator.testStruct has no field tagged start"},
+			fields:  []string{"start"},
+		},
+		{
+			validate: func(payload *listedMessage) {
+				Sanitize(payload).Params("stay").InZone("Mars/Olympus").ToTime(new(time.Time))
+			},
+			errMsgs: []string{"time zone Mars/Olympus of stay is unknown"},
+			fields:  []string{"stay"},
+		},
+		{
+			validate: func(payload *listedMessage) {
+				Sanitize(payload).Params("age").ToInt(0)
+			},
+			errMsgs: []string{"target of age should be pointer or map, not int"},
+			fields:  []string{"age"},
+		},
+		{
+			validate: func(payload *listedMessage) {
+				Check(payload).Strict().Params("age").IsExist()
+			},
+			errMsgs: []string{"field stay is unknown"},
+			fields:  []string{"stay"},
+		},
+	}
+	for _, tc := range cases {
+		payload := &listedMessage{message{msg: map[string]interface{}{
+			"age":  "18A",
+			"stay": `{"checkIn":"2020-11-06T00:00:00Z","checkOut":"2020-11-08T00:00:00Z","guests":"two"}`,
+		}}}
+		tc.validate(payload)
+		errs, _ := ValidateResult(payload)
+		errMsgs := []string{}
+		fields := []string{}
+		for _, err := range errs {
+			errMsgs = append(errMsgs, err.Error())
+			fields = append(fields, err.(fieldError).Field())
+		}
+		assert.Equal(t, tc.errMsgs, errMsgs)
+		assert.Equal(t, tc.fields, fields)
+	}
+}
+
+func TestInvalidStructErrorField(t *testing.T) {
+	assert.Equal(t, "checkIn", NewInvalidStructError("check in should be before check out", "checkIn", "checkOut").Field())
+	assert.Equal(t, "", NewInvalidStructError("too many guests").Field())
+}
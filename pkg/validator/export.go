@@ -0,0 +1,226 @@
+package validator
+
+import (
+	"math"
+	"reflect"
+	"time"
+)
+
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// TimeFormats set the time layouts of times for JSONSchema and OpenAPIParameters, they should be
+// the layouts used by sanitizing. time.RFC3339 is used when no layout is provided
+func (v *AnalyzeType) TimeFormats(layouts ...string) *AnalyzeType {
+	v.timeFormats = layouts
+	return v
+}
+
+// JSONSchema describe the tagged fields of struct in JSON Schema (draft 2020-12), the fields are
+// required unless they have optional option. It returns nil when the content isn't struct
+//
+//	doc, _ := json.Marshal(validator.Analyze(&user{}).JSONSchema())
+func (v *AnalyzeType) JSONSchema() map[string]interface{} {
+	structType := structTypeOf(v.content)
+	if structType == nil {
+		return nil
+	}
+	doc := v.objectSchema(structType, map[reflect.Type]bool{})
+	doc["$schema"] = jsonSchemaDialect
+	return doc
+}
+
+// OpenAPIParameters describe the tagged fields of struct in parameters of OpenAPI 3, the in is
+// the location of parameters, like "query" and "header". It returns nil when the content isn't struct
+func (v *AnalyzeType) OpenAPIParameters(in string) []map[string]interface{} {
+	structType := structTypeOf(v.content)
+	if structType == nil {
+		return nil
+	}
+	parameters := []map[string]interface{}{}
+	info := structInfoOf(structType)
+	for _, param := range info.tags {
+		field := info.byParam[param]
+		if !field.exported {
+			continue
+		}
+		_, optional := tagOption(field.options, "optional")
+		parameters = append(parameters, map[string]interface{}{
+			"name":     field.param,
+			"in":       in,
+			"required": !optional,
+			"schema":   v.typeSchema(field.fieldType, map[reflect.Type]bool{structType: true}),
+		})
+	}
+	return parameters
+}
+
+func (v *AnalyzeType) objectSchema(structType reflect.Type, visited map[reflect.Type]bool) map[string]interface{} {
+	visited[structType] = true
+	defer delete(visited, structType)
+	properties := map[string]interface{}{}
+	required := []string{}
+	info := structInfoOf(structType)
+	for _, param := range info.tags {
+		field := info.byParam[param]
+		if !field.exported {
+			continue
+		}
+		properties[field.param] = v.typeSchema(field.fieldType, visited)
+		if _, optional := tagOption(field.options, "optional"); !optional {
+			required = append(required, field.param)
+		}
+	}
+	doc := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		doc["required"] = required
+	}
+	return doc
+}
+
+// typeSchema describe the type of field, the tagged struct is described by its fields
+func (v *AnalyzeType) typeSchema(fieldType reflect.Type, visited map[reflect.Type]bool) map[string]interface{} {
+	if fieldType.Kind() == reflect.Ptr && fieldType != locationReflectType {
+		fieldType = fieldType.Elem()
+	}
+	switch {
+	case fieldType == timeReflectType:
+		return timeSchema(v.timeFormats)
+	case isTaggedStruct(fieldType):
+		if visited[fieldType] {
+			return map[string]interface{}{"type": "object"}
+		}
+		return v.objectSchema(fieldType, visited)
+	case fieldType.Kind() == reflect.Slice && fieldType != ipReflectType && fieldType.Elem().Kind() == reflect.Uint8:
+		return dataTypeSchema(bytesType)
+	case fieldType.Kind() == reflect.Slice && fieldType != ipReflectType:
+		return map[string]interface{}{"type": "array", "items": v.typeSchema(fieldType.Elem(), visited)}
+	}
+	switch fieldType.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint64:
+		if fieldType != durationReflectType {
+			return map[string]interface{}{"type": "integer"}
+		}
+	case reflect.Float32:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Map, reflect.Struct:
+		return map[string]interface{}{"type": "object"}
+	}
+	return dataTypeSchema(dataTypeOf(fieldType))
+}
+
+// dataTypeSchema describe the data type in JSON Schema
+func dataTypeSchema(dataType int) map[string]interface{} {
+	switch dataType {
+	case intType, int32Type, int64Type:
+		return map[string]interface{}{"type": "integer"}
+	case uint32Type:
+		return map[string]interface{}{"type": "integer", "minimum": 0, "maximum": math.MaxUint32}
+	case uint64Type:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case float64Type:
+		return map[string]interface{}{"type": "number"}
+	case boolType:
+		return map[string]interface{}{"type": "boolean"}
+	case stringType:
+		return map[string]interface{}{"type": "string"}
+	case bytesType:
+		return map[string]interface{}{"type": "string", "contentEncoding": "base64"}
+	case ipType:
+		return map[string]interface{}{"type": "string", "anyOf": []interface{}{
+			map[string]interface{}{"format": "ipv4"},
+			map[string]interface{}{"format": "ipv6"},
+		}}
+	case timeType, localTimeType:
+		return timeSchema(nil)
+	case durationType:
+		return map[string]interface{}{"type": "string", "format": "duration"}
+	case locationType:
+		return map[string]interface{}{"type": "string", "description": "IANA time zone, like Asia/Taipei"}
+	}
+	return map[string]interface{}{}
+}
+
+// timeSchema describe the time of layouts, the layouts other than RFC 3339 and date are
+// kept in x-layouts
+func timeSchema(layouts []string) map[string]interface{} {
+	if len(layouts) == 0 {
+		layouts = defaultTimeFormats
+	}
+	doc := map[string]interface{}{"type": "string"}
+	switch {
+	case len(layouts) == 1 && layouts[0] == time.RFC3339:
+		doc["format"] = "date-time"
+	case len(layouts) == 1 && layouts[0] == "2006-01-02":
+		doc["format"] = "date"
+	default:
+		doc["x-layouts"] = layouts
+	}
+	return doc
+}
+
+func structTypeOf(content interface{}) reflect.Type {
+	structType := reflect.TypeOf(content)
+	if structType != nil && structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType == nil || structType.Kind() != reflect.Struct {
+		return nil
+	}
+	return structType
+}
+
+// JSONSchema describe the fields of schema in JSON Schema (draft 2020-12), with the rules like
+// Min, Pattern and Format
+func (s *Schema) JSONSchema() map[string]interface{} {
+	doc := s.objectSchema()
+	doc["$schema"] = jsonSchemaDialect
+	return doc
+}
+
+// OpenAPIParameters describe the fields of schema in parameters of OpenAPI 3, the in is the
+// location of parameters, like "query" and "header"
+func (s *Schema) OpenAPIParameters(in string) []map[string]interface{} {
+	parameters := []map[string]interface{}{}
+	for _, field := range s.fields {
+		parameters = append(parameters, map[string]interface{}{
+			"name":     field.param,
+			"in":       in,
+			"required": !field.optional,
+			"schema":   field.jsonSchema(),
+		})
+	}
+	return parameters
+}
+
+func (s *Schema) objectSchema() map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []string{}
+	for _, field := range s.fields {
+		properties[field.param] = field.jsonSchema()
+		if !field.optional {
+			required = append(required, field.param)
+		}
+	}
+	doc := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		doc["required"] = required
+	}
+	return doc
+}
+
+func (f *schemaField) jsonSchema() map[string]interface{} {
+	doc := dataTypeSchema(f.dataType)
+	for keyword, value := range f.keywords {
+		doc[keyword] = value
+	}
+	if f.properties != nil {
+		for key, value := range f.properties.objectSchema() {
+			doc[key] = value
+		}
+	}
+	if f.items != nil {
+		doc["type"] = "array"
+		doc["items"] = f.items.jsonSchema()
+	}
+	return doc
+}
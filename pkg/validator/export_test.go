@@ -0,0 +1,98 @@
+package validator
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type exportStruct struct {
+	Name    string        `vld:"name,trimspace"`
+	Count   uint32        `vld:"count,optional"`
+	Timeout time.Duration `vld:"timeout"`
+	Tags    []string      `vld:"tags,optional"`
+	Body    *body         `vld:"body,optional"`
+	hidden  string        `vld:"hidden"`
+}
+
+func TestAnalyzeJSONSchema(t *testing.T) {
+	doc, err := json.Marshal(Analyze(&exportStruct{}).JSONSchema())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["name", "timeout"],
+		"properties": {
+			"name": {"type": "string"},
+			"count": {"type": "integer", "minimum": 0, "maximum": 4294967295},
+			"timeout": {"type": "string", "format": "duration"},
+			"tags": {"type": "array", "items": {"type": "string"}},
+			"body": {
+				"type": "object",
+				"required": ["foot"],
+				"properties": {
+					"foot": {
+						"type": "object",
+						"required": ["size", "bought"],
+						"properties": {
+							"size": {"type": "integer"},
+							"color": {"type": "string"},
+							"bought": {"type": "string", "format": "date-time"},
+							"shop": {"type": "string", "anyOf": [{"format": "ipv4"}, {"format": "ipv6"}]},
+							"bigToe": {"type": "object", "required": ["length"], "properties": {"length": {"type": "number"}}}
+						}
+					}
+				}
+			}
+		}
+	}`, string(doc))
+
+	doc, _ = json.Marshal(Analyze(foot{}).TimeFormats("2006-01-02").JSONSchema()["properties"].(map[string]interface{})["bought"])
+	assert.JSONEq(t, `{"type": "string", "format": "date"}`, string(doc))
+	doc, _ = json.Marshal(Analyze(foot{}).TimeFormats(DateTimeFormats...).JSONSchema()["properties"].(map[string]interface{})["bought"])
+	assert.Contains(t, string(doc), `"x-layouts":["2006-01-02 15:04:05"`)
+	assert.Nil(t, Analyze(3).JSONSchema())
+}
+
+func TestAnalyzeOpenAPIParameters(t *testing.T) {
+	doc, err := json.Marshal(Analyze(&zoneStruct{}).OpenAPIParameters("query"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"name": "start", "in": "query", "required": true, "schema": {"type": "string", "format": "date-time"}},
+		{"name": "end", "in": "query", "required": true, "schema": {"type": "string", "format": "date-time"}},
+		{"name": "bad", "in": "query", "required": true, "schema": {"type": "string", "format": "date-time"}},
+		{"name": "zone", "in": "query", "required": true, "schema": {"type": "string", "description": "IANA time zone, like Asia/Taipei"}}
+	]`, string(doc))
+	assert.Nil(t, Analyze("zone").OpenAPIParameters("query"))
+}
+
+func TestSchemaJSONSchema(t *testing.T) {
+	schema := NewSchema().
+		Field("age", Int(), Min(0), Max(150)).
+		Field("code", String(), Optional(), Pattern(regexp.MustCompile("^[A-Z]{3}$")), MaxLength(3)).
+		Field("tags", Items(String(), Enum("a", "b")))
+	doc, err := json.Marshal(schema.JSONSchema())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["age", "tags"],
+		"properties": {
+			"age": {"type": "integer", "minimum": 0, "maximum": 150},
+			"code": {"type": "string", "pattern": "^[A-Z]{3}$", "maxLength": 3},
+			"tags": {"type": "array", "items": {"type": "string", "enum": ["a", "b"]}}
+		}
+	}`, string(doc))
+
+	imported, err := FromJSONSchema(doc)
+	assert.NoError(t, err)
+	params, _ := json.Marshal(imported.OpenAPIParameters("query"))
+	assert.JSONEq(t, `[
+		{"name": "age", "in": "query", "required": true, "schema": {"type": "integer", "minimum": 0, "maximum": 150}},
+		{"name": "code", "in": "query", "required": false, "schema": {"type": "string", "pattern": "^[A-Z]{3}$", "maxLength": 3}},
+		{"name": "tags", "in": "query", "required": true, "schema": {"type": "array", "items": {"type": "string", "enum": ["a", "b"]}}}
+	]`, string(params))
+}
@@ -0,0 +1,286 @@
+package validator
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"time"
+)
+
+// Fields sanitize params without reflection, it is used by the code generated by vldgen.
+// The options are the options of vld tag, like "optional" and "trimspace", and the errors
+// and absence are reported to the session of payload like SanitizeType.
+type Fields struct {
+	session *SanitizeType
+	start   int
+}
+
+// NewFields return Fields to sanitize params of payload
+func NewFields(payload Payload) *Fields {
+	session := Sanitize(payload)
+	return &Fields{session: session, start: errorCount(payload)}
+}
+
+// Int sanitize param to int
+func (f *Fields) Int(param string, options ...string) (int, bool) {
+	val, ok := f.value(param, intType, options)
+	if !ok {
+		return 0, false
+	}
+	return val.(int), true
+}
+
+// Uint32 sanitize param to uint32
+func (f *Fields) Uint32(param string, options ...string) (uint32, bool) {
+	val, ok := f.value(param, uint32Type, options)
+	if !ok {
+		return 0, false
+	}
+	return val.(uint32), true
+}
+
+// Float64 sanitize param to float64
+func (f *Fields) Float64(param string, options ...string) (float64, bool) {
+	val, ok := f.value(param, float64Type, options)
+	if !ok {
+		return 0, false
+	}
+	return val.(float64), true
+}
+
+// Bool sanitize param to bool
+func (f *Fields) Bool(param string, options ...string) (bool, bool) {
+	val, ok := f.value(param, boolType, options)
+	if !ok {
+		return false, false
+	}
+	return val.(bool), true
+}
+
+// String sanitize param to string
+func (f *Fields) String(param string, options ...string) (string, bool) {
+	val, ok := f.value(param, stringType, options)
+	if !ok {
+		return "", false
+	}
+	return val.(string), true
+}
+
+// IP sanitize param to ip
+func (f *Fields) IP(param string, options ...string) (net.IP, bool) {
+	val, ok := f.value(param, ipType, options)
+	if !ok {
+		return nil, false
+	}
+	return val.(net.IP), true
+}
+
+// Time sanitize param to time
+func (f *Fields) Time(param string, options ...string) (time.Time, bool) {
+	val, ok := f.value(param, timeType, options)
+	if !ok {
+		return time.Time{}, false
+	}
+	return val.(time.Time), true
+}
+
+// Duration sanitize param to duration
+func (f *Fields) Duration(param string, options ...string) (time.Duration, bool) {
+	val, ok := f.value(param, durationType, options)
+	if !ok {
+		return 0, false
+	}
+	return val.(time.Duration), true
+}
+
+// Location sanitize param to *time.Location
+func (f *Fields) Location(param string, options ...string) (*time.Location, bool) {
+	val, ok := f.value(param, locationType, options)
+	if !ok {
+		return nil, false
+	}
+	return val.(*time.Location), true
+}
+
+// JSON decode param into out, which should be pointer. Like ToStruct, the struct with vld tags
+// is sanitized by its tags
+func (f *Fields) JSON(param string, out interface{}, options ...string) bool {
+	v := f.session
+	v.setParam(param)
+	_, v.optional = tagOption(options, "optional")
+	v.out = nil
+	val, exist := v.handleAbsence()
+	if !exist {
+		return false
+	}
+	var err error
+	target := reflect.ValueOf(out)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		err = newConfigError(fmt.Sprintf("target of %s should be pointer, not %T", param, out), "param", param)
+	} else {
+		err = v.decodeJSON(v.transformJSON(val, options, target.Elem()), target.Elem())
+	}
+	v.handleErrors(err)
+	return err == nil
+}
+
+// Validate run the struct-level rules of out, which are the Validate of StructValidator and the
+// rules registered by RegisterStructRule, like ToStruct after sanitizing. The rules are skipped
+// when the fields of out have failed or required fields are absent
+func (f *Fields) Validate(out interface{}) {
+	v := f.session
+	session := v.content.GetCache()[contextKey].(map[string]interface{})
+	invalid, _ := session[invalidKey].(map[string]bool)
+	failFast, _ := session[failFastKey].(bool)
+	target := structTarget{out: out}
+	if structInfoOfTarget(out) == nil || isBrokenStruct(target, invalid) {
+		return
+	}
+	errorList, _ := session[errorsKey].([]error)
+	for _, err := range structErrors(out) {
+		if failFast && len(errorList) > 0 {
+			break
+		}
+		errorList = append(errorList, attributeStructError(target, err))
+	}
+	session[errorsKey] = errorList
+}
+
+// Err return the first error reported by the fields
+func (f *Fields) Err() error {
+	return errorSince(f.session.content, f.start)
+}
+
+func (f *Fields) value(param string, dataType int, options []string) (interface{}, bool) {
+	v := f.session
+	v.setParam(param)
+	_, v.optional = tagOption(options, "optional")
+	v.out = nil
+	val, exist := v.handleAbsence()
+	if !exist {
+		return nil, false
+	}
+	valInstance, err := v.convert(v.applyTransforms(val, options), dataType, options)
+	v.handleErrors(err)
+	return valInstance, err == nil
+}
+
+// Checks check types of params without reflection, it is used by the code generated by vldgen.
+// The errors and absence are reported to the session of payload like CheckType, and a param
+// with "optional" option is not reported when it is absent.
+type Checks struct {
+	session *CheckType
+	start   int
+}
+
+// NewChecks return Checks to check params of payload
+func NewChecks(payload Payload) *Checks {
+	session := Check(payload)
+	return &Checks{session: session, start: errorCount(payload)}
+}
+
+// Exist check param is exist
+func (c *Checks) Exist(param string, options ...string) {
+	c.is(param, options, "", nil)
+}
+
+// Int check param is int
+func (c *Checks) Int(param string, options ...string) {
+	c.is(param, options, "int", func(val interface{}) bool {
+		_, ok := val.(int)
+		return ok
+	})
+}
+
+// Int32 check param is int32
+func (c *Checks) Int32(param string, options ...string) {
+	c.is(param, options, "int32", func(val interface{}) bool {
+		_, ok := val.(int32)
+		return ok
+	})
+}
+
+// Int64 check param is int64
+func (c *Checks) Int64(param string, options ...string) {
+	c.is(param, options, "int64", func(val interface{}) bool {
+		_, ok := val.(int64)
+		return ok
+	})
+}
+
+// Uint32 check param is uint32
+func (c *Checks) Uint32(param string, options ...string) {
+	c.is(param, options, "uint32", func(val interface{}) bool {
+		_, ok := val.(uint32)
+		return ok
+	})
+}
+
+// Uint64 check param is uint64
+func (c *Checks) Uint64(param string, options ...string) {
+	c.is(param, options, "uint64", func(val interface{}) bool {
+		_, ok := val.(uint64)
+		return ok
+	})
+}
+
+// Float check param is float64
+func (c *Checks) Float(param string, options ...string) {
+	c.is(param, options, "float64", func(val interface{}) bool {
+		_, ok := val.(float64)
+		return ok
+	})
+}
+
+// Bool check param is bool
+func (c *Checks) Bool(param string, options ...string) {
+	c.is(param, options, "bool", func(val interface{}) bool {
+		_, ok := val.(bool)
+		return ok
+	})
+}
+
+// String check param is string
+func (c *Checks) String(param string, options ...string) {
+	c.is(param, options, "string", func(val interface{}) bool {
+		_, ok := val.(string)
+		return ok
+	})
+}
+
+// Bytes check param is bytes
+func (c *Checks) Bytes(param string, options ...string) {
+	c.is(param, options, "bytes", func(val interface{}) bool {
+		_, ok := val.([]byte)
+		return ok
+	})
+}
+
+// Err return the first error reported by the checks
+func (c *Checks) Err() error {
+	return errorSince(c.session.content, c.start)
+}
+
+func (c *Checks) is(param string, options []string, typeName string, isType func(interface{}) bool) {
+	v := c.session
+	v.setParam(param)
+	_, v.optional = tagOption(options, "optional")
+	val, exist := v.handleAbsence()
+	if exist && isType != nil && !isType(val) {
+		v.handleErrors(newTypeError(param, typeName))
+	}
+}
+
+func errorCount(payload Payload) int {
+	errorList, _ := payload.GetCache()[contextKey].(map[string]interface{})[errorsKey].([]error)
+	return len(errorList)
+}
+
+// errorSince get the first error of session after the count of errors
+func errorSince(payload Payload, start int) error {
+	errorList, _ := payload.GetCache()[contextKey].(map[string]interface{})[errorsKey].([]error)
+	if len(errorList) > start {
+		return errorList[start]
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package validator
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFields(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"age":   " 18 ",
+		"name":  " ken ",
+		"ip":    "127.0.0.1",
+		"start": "2020-11-06T08:00:00+08:00",
+		"zone":  "Asia/Taipei",
+		"foot":  `{"size": 42, "bought": "2020-11-06T00:00:00Z", "color": "red", "shop": "10.0.0.1", "bigToe": {"length": 3}}`,
+		"score": "60A",
+	}}
+	fields := NewFields(payload)
+	age, ok := fields.Int("age", "trimspace")
+	assert.Equal(t, 18, age)
+	assert.True(t, ok)
+	name, _ := fields.String("name", "trimspace", "upper")
+	assert.Equal(t, "KEN", name)
+	ip, _ := fields.IP("ip")
+	assert.Equal(t, net.ParseIP("127.0.0.1"), ip)
+	start, _ := fields.Time("start", "tz=Asia/Taipei", "utc")
+	assert.Equal(t, time.Date(2020, 11, 6, 0, 0, 0, 0, time.UTC), start)
+	zone, _ := fields.Location("zone")
+	assert.Equal(t, "Asia/Taipei", zone.String())
+	var f foot
+	assert.True(t, fields.JSON("foot", &f))
+	assert.Equal(t, 42, f.Size)
+	assert.NoError(t, fields.Err())
+
+	score, ok := fields.Int("score")
+	assert.Equal(t, 0, score)
+	assert.False(t, ok)
+	_, ok = fields.Float64("hp", "optional")
+	assert.False(t, ok)
+	_, ok = fields.Bool("alive")
+	assert.False(t, ok)
+	assert.EqualError(t, fields.Err(), "field score type is not int")
+	errs, absence := ValidateResult(payload)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, []string{"hp", "alive"}, absence)
+}
+
+func TestFieldsValidate(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"checkIn":  "2020-11-08T00:00:00Z",
+		"checkOut": "2020-11-06T00:00:00Z",
+		"guests":   "5",
+	}}
+	fields := NewFields(payload)
+	var s stay
+	s.CheckIn, _ = fields.Time("checkIn")
+	s.CheckOut, _ = fields.Time("checkOut")
+	s.Guests, _ = fields.Int("guests", "optional")
+	fields.Validate(&s)
+	assert.EqualError(t, fields.Err(), "check in should be before check out")
+	errs, _ := ValidateResult(payload)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, []string{"checkIn", "checkOut"}, errs[0].(InvalidStructError).Params)
+	assert.EqualError(t, errs[1], "too many guests")
+
+	payload = &message{msg: map[string]interface{}{"checkIn": "2020-11-08T00:00:00Z", "guests": "5"}}
+	fields = NewFields(payload)
+	s = stay{}
+	s.CheckIn, _ = fields.Time("checkIn")
+	s.CheckOut, _ = fields.Time("checkOut")
+	fields.Validate(&s)
+	errs, _ = ValidateResult(payload)
+	assert.Equal(t, 1, len(errs))
+	assert.EqualError(t, errs[0], "field checkOut doesn't exist")
+}
+
+func TestChecks(t *testing.T) {
+	type testCase struct {
+		dataReq *message
+		check   func(checks *Checks)
+		errMsg  string
+		absence []string
+	}
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{"age": 18, "name": "ken", "data": []byte("ok")}},
+			check: func(checks *Checks) {
+				checks.Int("age")
+				checks.String("name")
+				checks.Bytes("data")
+				checks.Bool("alive", "optional")
+			},
+			absence: []string{"alive"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{"age": "18", "score": 60}},
+			check: func(checks *Checks) {
+				checks.Int("age")
+				checks.Float("score")
+				checks.Exist("name")
+			},
+			errMsg:  "field age type is not int",
+			absence: []string{"name"},
+		},
+	}
+	for _, c := range cases {
+		checks := NewChecks(c.dataReq)
+		c.check(checks)
+		if c.errMsg == "" {
+			assert.NoError(t, checks.Err())
+		} else {
+			assert.EqualError(t, checks.Err(), c.errMsg)
+		}
+		_, absence := ValidateResult(c.dataReq)
+		assert.Equal(t, c.absence, absence)
+	}
+}
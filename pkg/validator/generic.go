@@ -0,0 +1,53 @@
+package validator
+
+import (
+	"reflect"
+)
+
+// Rule check the sanitized value of Param
+type Rule[T any] func(val T) error
+
+// Get sanitize param of session to type T, the errors and absence are reported to session like
+// ToX of SanitizeType, so the options of session like TrimSpace and TimeFormats are used.
+//
+//	age := validator.Get[int](validator.Sanitize(payload), "age")
+func Get[T any](session *SanitizeType, param string) T {
+	values := map[string]T{}
+	session.Params(param).toValue(values, dataTypeOf(reflect.TypeOf((*T)(nil)).Elem()))
+	return values[param]
+}
+
+// Param sanitize param of payload to type T and check it by rules, it returns the first error.
+// The session of payload is untouched.
+//
+//	age, err := validator.Param[int](payload, "age")
+func Param[T any](payload Payload, param string, rules ...Rule[T]) (T, error) {
+	isolated := &isolatedPayload{Payload: payload}
+	val := Get[T](Sanitize(isolated), param)
+	if errs, _ := ValidateResult(isolated); len(errs) > 0 {
+		return val, errs[0]
+	}
+	for _, rule := range rules {
+		if err := rule(val); err != nil {
+			return val, err
+		}
+	}
+	return val, nil
+}
+
+// isolatedPayload is payload with its own cache, so it has a session apart from the payload
+type isolatedPayload struct {
+	Payload
+	cache map[string]interface{}
+}
+
+func (p *isolatedPayload) GetCache() map[string]interface{} {
+	if p.cache == nil {
+		p.cache = make(map[string]interface{})
+	}
+	return p.cache
+}
+
+func (p *isolatedPayload) SetCache(input map[string]interface{}) {
+	p.cache = input
+}
@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"age":     " 18 ",
+		"name":    "ken",
+		"start":   "2020-11-06",
+		"timeout": "PT1M",
+		"big":     "18446744073709551615",
+		"foot":    `{"size": 42, "bought": "2020-11-06"}`,
+		"score":   "60A",
+	}}
+	session := Sanitize(payload).TrimSpace().TimeFormat("2006-01-02")
+	assert.Equal(t, 18, Get[int](session, "age"))
+	assert.Equal(t, 18, *Get[*int](session, "age"))
+	assert.Equal(t, "ken", Get[string](session, "name"))
+	assert.Equal(t, time.Date(2020, 11, 6, 0, 0, 0, 0, time.UTC), Get[time.Time](session, "start"))
+	assert.Equal(t, time.Minute, Get[time.Duration](session, "timeout"))
+	assert.Equal(t, uint64(18446744073709551615), Get[uint64](session, "big"))
+	assert.Equal(t, 42, Get[foot](session, "foot").Size)
+	assert.Equal(t, 0, Get[int](session, "score"))
+	assert.Equal(t, 0, Get[int](session, "hp"))
+	errs, absence := ValidateResult(payload)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, []string{"foot.color", "foot.shop", "foot.bigToe", "hp"}, absence)
+}
+
+func TestParam(t *testing.T) {
+	type testCase struct {
+		dataReq *message
+		rules   []Rule[int]
+		want    int
+		wantErr error
+	}
+	errTooYoung := errors.New("too young")
+	adult := func(age int) error {
+		if age < 18 {
+			return errTooYoung
+		}
+		return nil
+	}
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"age": "18",
+			}},
+			rules: []Rule[int]{adult},
+			want:  18,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"age": "17",
+			}},
+			rules:   []Rule[int]{adult},
+			want:    17,
+			wantErr: errTooYoung,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"age": "18A",
+			}},
+			wantErr: WrongTypeError{},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{}},
+			wantErr: NotExistError{},
+		},
+	}
+	for _, tc := range cases {
+		Check(tc.dataReq).Params("name").IsExist()
+		actual, err := Param[int](tc.dataReq, "age", tc.rules...)
+		assert.Equal(t, tc.want, actual)
+		assert.IsType(t, tc.wantErr, err)
+		if tc.wantErr == errTooYoung {
+			assert.Equal(t, errTooYoung, err)
+		}
+		errs, absence := ValidateResult(tc.dataReq)
+		assert.Equal(t, 1, len(errs))
+		assert.Equal(t, []string{"name"}, absence)
+	}
+}
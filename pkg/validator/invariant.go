@@ -0,0 +1,120 @@
+package validator
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// StructValidator is struct which validates itself after sanitizing, like the rule across its fields.
+// The error can be InvalidStructError to tell the fields breaking the rule
+type StructValidator interface {
+	Validate() error
+}
+
+// structRules are the registered struct-level rules, map[reflect.Type][]func(interface{}) error
+var (
+	structRules   sync.Map
+	structRulesMu sync.Mutex
+)
+
+// structTarget is the struct sanitized in session, prefix is the path of nested struct
+type structTarget struct {
+	out    interface{}
+	prefix string
+}
+
+// RegisterStructRule register the struct-level rule of T, it runs after sanitizing into *T like
+// the Validate of StructValidator. It is usually called in init
+func RegisterStructRule[T any](rule func(out *T) error) {
+	structType := reflect.TypeOf((*T)(nil)).Elem()
+	structRulesMu.Lock()
+	defer structRulesMu.Unlock()
+	rules, _ := structRules.Load(structType)
+	newRules, _ := rules.([]func(interface{}) error)
+	newRules = append(newRules[:len(newRules):len(newRules)], func(out interface{}) error {
+		return rule(out.(*T))
+	})
+	structRules.Store(structType, newRules)
+}
+
+// recordStruct record the struct target, its struct-level rules run by ValidateResult
+func (v *SanitizeType) recordStruct(out interface{}) {
+	if reflect.TypeOf(out).Kind() != reflect.Ptr || reflect.ValueOf(out).IsNil() {
+		return
+	}
+	cache := v.content.GetCache()
+	targets, _ := cache[contextKey].(map[string]interface{})[structKey].([]structTarget)
+	for _, target := range targets {
+		if target.out == out && target.prefix == v.prefix {
+			return
+		}
+	}
+	cache[contextKey].(map[string]interface{})[structKey] = append(targets, structTarget{out: out, prefix: v.prefix})
+}
+
+// validateStructs run the struct-level rules of the structs sanitized in session, the struct whose
+// fields have failed or required fields are absent is skipped
+func validateStructs(payload Payload, errorList []error) []error {
+	session := payload.GetCache()[contextKey].(map[string]interface{})
+	targets, _ := session[structKey].([]structTarget)
+	invalid, _ := session[invalidKey].(map[string]bool)
+	failFast, _ := session[failFastKey].(bool)
+	for _, target := range targets {
+		if isBrokenStruct(target, invalid) {
+			continue
+		}
+		for _, err := range structErrors(target.out) {
+			if failFast && len(errorList) > 0 {
+				return errorList
+			}
+			errorList = append(errorList, attributeStructError(target, err))
+		}
+	}
+	return errorList
+}
+
+func isBrokenStruct(target structTarget, invalid map[string]bool) bool {
+	for _, param := range structInfoOfTarget(target.out).tags {
+		if invalid[target.prefix+param] {
+			return true
+		}
+	}
+	return false
+}
+
+func structErrors(out interface{}) []error {
+	var errorList []error
+	if validator, ok := out.(StructValidator); ok {
+		if err := validator.Validate(); err != nil {
+			errorList = append(errorList, err)
+		}
+	}
+	rules, _ := structRules.Load(reflect.TypeOf(out).Elem())
+	registered, _ := rules.([]func(interface{}) error)
+	for _, rule := range registered {
+		if err := rule(out); err != nil {
+			errorList = append(errorList, err)
+		}
+	}
+	return errorList
+}
+
+// attributeStructError convert error to InvalidStructError whose params have the path of struct,
+// the go field names are converted to params
+func attributeStructError(target structTarget, err error) error {
+	var structErr InvalidStructError
+	if !errors.As(err, &structErr) {
+		structErr = InvalidStructError{basicError: basicError{message: err.Error(), code: "struct"}, err: err}
+	}
+	params := make([]string, len(structErr.Params))
+	analyze := Analyze(target.out)
+	for i, field := range structErr.Params {
+		if param, ok := analyze.ParamOf(field); ok {
+			field = param
+		}
+		params[i] = target.prefix + field
+	}
+	structErr.Params = params
+	return structErr
+}
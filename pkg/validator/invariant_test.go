@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stay struct {
+	CheckIn  time.Time `vld:"checkIn"`
+	CheckOut time.Time `vld:"checkOut"`
+	Guests   int       `vld:"guests,optional"`
+}
+
+func (s *stay) Validate() error {
+	if !s.CheckIn.Before(s.CheckOut) {
+		return NewInvalidStructError("check in should be before check out", "CheckIn", "checkOut")
+	}
+	return nil
+}
+
+type trip struct {
+	Stay stay   `vld:"stay"`
+	Name string `vld:"name"`
+}
+
+func init() {
+	RegisterStructRule(func(s *stay) error {
+		if s.Guests > 4 {
+			return errors.New("too many guests")
+		}
+		return nil
+	})
+}
+
+func TestStructValidator(t *testing.T) {
+	type testCase struct {
+		dataReq *message
+		errMsgs []string
+		params  [][]string
+	}
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{"checkIn": "2020-11-06T00:00:00Z", "checkOut": "2020-11-08T00:00:00Z"}},
+			errMsgs: []string{},
+			params:  [][]string{},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{"checkIn": "2020-11-08T00:00:00Z", "checkOut": "2020-11-06T00:00:00Z", "guests": "5"}},
+			errMsgs: []string{"check in should be before check out", "too many guests"},
+			params:  [][]string{{"checkIn", "checkOut"}, {}},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{"checkIn": "2020-11-08T00:00:00Z"}},
+			errMsgs: []string{"field checkOut doesn't exist"},
+			params:  [][]string{},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{"checkIn": "2020-11-08", "checkOut": "2020-11-06T00:00:00Z"}},
+			errMsgs: []string{"field checkIn type is not time in layouts [\"2006-01-02T15:04:05Z07:00\"]. parse error: parsing time \"2020-11-08\" as \"2006-01-02T15:04:05Z07:00\": cannot parse \"\" as \"T\""},
+			params:  [][]string{},
+		},
+	}
+	for _, c := range cases {
+		actual := stay{}
+		Sanitize(c.dataReq).Params("checkIn").ToTime(&actual)
+		Sanitize(c.dataReq).Params("checkOut").ToTime(&actual)
+		Sanitize(c.dataReq).Params("guests").Optional().ToInt(&actual)
+		errs, _ := ValidateResult(c.dataReq)
+		errMsgs := []string{}
+		params := [][]string{}
+		for _, err := range errs {
+			errMsgs = append(errMsgs, err.Error())
+			if structErr, ok := err.(InvalidStructError); ok {
+				params = append(params, structErr.Params)
+			}
+		}
+		assert.Equal(t, c.errMsgs, errMsgs)
+		assert.Equal(t, c.params, params)
+	}
+}
+
+func TestStructValidatorNested(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"stay": `{"checkIn": "2020-11-08T00:00:00Z", "checkOut": "2020-11-06T00:00:00Z", "guests": 6}`,
+		"name": "ken",
+	}}
+	actual := trip{}
+	Sanitize(payload).FailFast().Params("stay").ToStruct(&actual)
+	Sanitize(payload).Params("name").ToString(&actual)
+	errs, _ := ValidateResult(payload)
+	if assert.Equal(t, 1, len(errs)) {
+		structErr, ok := errs[0].(InvalidStructError)
+		assert.True(t, ok)
+		assert.Equal(t, []string{"stay.checkIn", "stay.checkOut"}, structErr.Params)
+	}
+
+	payload.msg["stay"] = `{"checkIn": "2020-11-06T00:00:00Z", "checkOut": "2020-11-08T00:00:00Z", "guests": 6}`
+	Sanitize(payload).Params("stay").ToStruct(&actual)
+	errs, _ = ValidateResult(payload)
+	if assert.Equal(t, 1, len(errs)) {
+		assert.EqualError(t, errs[0], "too many guests")
+		assert.True(t, errors.As(errs[0], new(InvalidStructError)))
+		assert.Equal(t, "too many guests", errors.Unwrap(errs[0]).Error())
+	}
+}
@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// jsonOptions are the options of decoding json message by ToObject, ToStruct and ToJSONString
+type jsonOptions struct {
+	disallowUnknownFields bool
+	useNumber             bool
+	maxDepth              int
+	maxBytes              int
+}
+
+// DisallowUnknownFields report error when json object has keys not matching any field of target
+func (v *SanitizeType) DisallowUnknownFields() *SanitizeType {
+	v.jsonOptions.disallowUnknownFields = true
+	return v
+}
+
+// UseNumber decode numbers of json into interface{} as json.Number instead of float64
+func (v *SanitizeType) UseNumber() *SanitizeType {
+	v.jsonOptions.useNumber = true
+	return v
+}
+
+// MaxDepth limit the nesting depth of json objects and arrays
+func (v *SanitizeType) MaxDepth(depth int) *SanitizeType {
+	v.jsonOptions.maxDepth = depth
+	return v
+}
+
+// MaxBytes limit the size of json message
+func (v *SanitizeType) MaxBytes(size int) *SanitizeType {
+	v.jsonOptions.maxBytes = size
+	return v
+}
+
+// decodeJSON decode json message into field, if field is a struct with vld tags, its fields are
+// sanitized by their tags like top-level params
+func (v *SanitizeType) decodeJSON(val string, field reflect.Value) error {
+	if v.jsonOptions.maxBytes > 0 && len(val) > v.jsonOptions.maxBytes {
+		return newInvalidValueError(fmt.Sprintf("field %s is larger than %d bytes", v.getParam(), v.jsonOptions.maxBytes), "max_bytes", "param", v.getParam(), "limit", v.jsonOptions.maxBytes)
+	}
+	if v.jsonOptions.maxDepth > 0 && jsonDepth(val) > v.jsonOptions.maxDepth {
+		return newInvalidValueError(fmt.Sprintf("field %s is deeper than %d", v.getParam(), v.jsonOptions.maxDepth), "max_depth", "param", v.getParam(), "limit", v.jsonOptions.maxDepth)
+	}
+	if isTaggedStruct(field.Type()) {
+		return v.sanitizeNested(val, field)
+	}
+	decoder := json.NewDecoder(strings.NewReader(val))
+	if v.jsonOptions.disallowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if v.jsonOptions.useNumber {
+		decoder.UseNumber()
+	}
+	err := decoder.Decode(field.Addr().Interface())
+	if err == nil {
+		if _, trailingErr := decoder.Token(); trailingErr != io.EOF {
+			err = fmt.Errorf("invalid character after top-level value")
+		}
+	}
+	if err != nil {
+		return newTypeError(v.getParam(), "json", "value", val)
+	}
+	return nil
+}
+
+// jsonDepth get the nesting depth of json objects and arrays, a scalar value has depth 0
+func jsonDepth(val string) int {
+	decoder := json.NewDecoder(strings.NewReader(val))
+	depth, maxDepth := 0, 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return maxDepth
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+			if depth > maxDepth {
+				maxDepth = depth
+			}
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+}
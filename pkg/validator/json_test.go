@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeJSONOptions(t *testing.T) {
+	type testCase struct {
+		dataReq         *message
+		dataField       string
+		options         func(v *SanitizeType) *SanitizeType
+		want            testStruct
+		wantFormatError int
+	}
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"leg": `{"number": 2, "toe": 5}`,
+			}},
+			dataField: "leg",
+			options:   func(v *SanitizeType) *SanitizeType { return v },
+			want:      testStruct{Leg: leg{Number: 2}},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"leg": `{"number": 2, "toe": 5}`,
+			}},
+			dataField:       "leg",
+			options:         (*SanitizeType).DisallowUnknownFields,
+			want:            testStruct{Leg: leg{Number: 2}},
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"hand": `{"finger": 5}`,
+			}},
+			dataField: "hand",
+			options:   (*SanitizeType).UseNumber,
+			want:      testStruct{Hand: map[string]interface{}{"finger": json.Number("5")}},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"hand": `{"finger": {"nail": [1]}}`,
+			}},
+			dataField:       "hand",
+			options:         func(v *SanitizeType) *SanitizeType { return v.MaxDepth(2) },
+			want:            testStruct{},
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"hand": `{"finger": {"nail": 1}}`,
+			}},
+			dataField: "hand",
+			options:   func(v *SanitizeType) *SanitizeType { return v.MaxDepth(2) },
+			want:      testStruct{Hand: map[string]interface{}{"finger": map[string]interface{}{"nail": float64(1)}}},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"hand": `{"finger": 5}`,
+			}},
+			dataField:       "hand",
+			options:         func(v *SanitizeType) *SanitizeType { return v.MaxBytes(8) },
+			want:            testStruct{},
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"hand": `{"finger": 5} {}`,
+			}},
+			dataField:       "hand",
+			options:         func(v *SanitizeType) *SanitizeType { return v },
+			want:            testStruct{Hand: map[string]interface{}{"finger": float64(5)}},
+			wantFormatError: 1,
+		},
+	}
+	for _, tc := range cases {
+		actual := testStruct{}
+		tc.options(Sanitize(tc.dataReq).Params(tc.dataField)).ToStruct(&actual)
+		errs, _ := ValidateResult(tc.dataReq)
+		assert.Equal(t, tc.wantFormatError, len(errs))
+		assert.Equal(t, tc.want, actual)
+	}
+}
+
+func TestJSONDepth(t *testing.T) {
+	assert.Equal(t, 0, jsonDepth(`1`))
+	assert.Equal(t, 1, jsonDepth(`[1, 2]`))
+	assert.Equal(t, 3, jsonDepth(`{"a": [{"b": 1}], "c": {}}`))
+}
@@ -0,0 +1,298 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// jsonSchemaAnnotations are the keywords of JSON Schema which don't validate, they are ignored
+var jsonSchemaAnnotations = map[string]bool{
+	"$schema":     true,
+	"$id":         true,
+	"$comment":    true,
+	"title":       true,
+	"description": true,
+	"default":     true,
+	"examples":    true,
+	"deprecated":  true,
+	"readOnly":    true,
+	"writeOnly":   true,
+}
+
+// jsonSchemaParser translate JSON Schema into schema, and collect the unsupported keywords
+type jsonSchemaParser struct {
+	unsupported []string
+}
+
+// FromJSONSchema build schema from JSON Schema (draft 2020-12). The keywords type, required,
+// minimum, maximum, minLength, maxLength, pattern, enum, format, properties and items are
+// translated, and the annotations like title and description are ignored. Other keywords are
+// reported as SchemaError, so the document is never checked partly.
+//
+// The rules check the values decoded from json, like float64 and map[string]interface{},
+// so the schema is usually applied by Validate.
+func FromJSONSchema(data []byte) (*Schema, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var root interface{}
+	if err := decoder.Decode(&root); err != nil {
+		line := 0
+		if syntaxErr, ok := err.(*json.SyntaxError); ok {
+			line = bytes.Count(data[:syntaxErr.Offset], []byte("\n")) + 1
+		}
+		return nil, newSchemaError(line, err.Error())
+	}
+	object, ok := root.(map[string]interface{})
+	if !ok {
+		return nil, newSchemaError(0, "json schema should be object")
+	}
+	parser := &jsonSchemaParser{}
+	for keyword, value := range object {
+		switch keyword {
+		case "type":
+			if value != "object" {
+				return nil, newSchemaError(0, "type of json schema should be object")
+			}
+		case "properties", "required":
+		default:
+			if !jsonSchemaAnnotations[keyword] {
+				parser.unsupported = append(parser.unsupported, "/"+keyword)
+			}
+		}
+	}
+	schema, err := parser.object(object, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(parser.unsupported) > 0 {
+		sort.Strings(parser.unsupported)
+		return nil, newSchemaError(0, "json schema has unsupported keywords: "+strings.Join(parser.unsupported, ", "))
+	}
+	return schema, nil
+}
+
+// object translate the schema of object, its properties are the fields of schema
+func (p *jsonSchemaParser) object(object map[string]interface{}, path string) (*Schema, error) {
+	schema := NewSchema()
+	required := map[string]bool{}
+	if list, ok := object["required"]; ok {
+		names, ok := list.([]interface{})
+		if !ok {
+			return nil, newSchemaError(0, fmt.Sprintf("required at %s/required should be array", path))
+		}
+		for _, name := range names {
+			nameStr, ok := name.(string)
+			if !ok {
+				return nil, newSchemaError(0, fmt.Sprintf("required at %s/required should be array of string", path))
+			}
+			required[nameStr] = true
+		}
+	}
+	properties := map[string]interface{}{}
+	if value, ok := object["properties"]; ok {
+		if properties, ok = value.(map[string]interface{}); !ok {
+			return nil, newSchemaError(0, fmt.Sprintf("properties at %s/properties should be object", path))
+		}
+	}
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property, ok := properties[name].(map[string]interface{})
+		if !ok {
+			return nil, newSchemaError(0, fmt.Sprintf("schema at %s/properties/%s should be object", path, name))
+		}
+		options, err := p.field(property, path+"/properties/"+name)
+		if err != nil {
+			return nil, err
+		}
+		if !required[name] {
+			options = append(options, Optional())
+		}
+		schema = schema.Field(name, options...)
+	}
+	for _, name := range sortedKeys(required) {
+		if _, ok := properties[name]; !ok {
+			schema = schema.Field(name)
+		}
+	}
+	return schema, nil
+}
+
+// field translate the keywords of schema into the options of field
+func (p *jsonSchemaParser) field(object map[string]interface{}, path string) ([]FieldOption, error) {
+	options := []FieldOption{}
+	keywords := make([]string, 0, len(object))
+	for keyword := range object {
+		keywords = append(keywords, keyword)
+	}
+	// type goes first, so the value of wrong type is not reported by the rules of other keywords
+	sort.Slice(keywords, func(i, j int) bool {
+		if (keywords[i] == "type") != (keywords[j] == "type") {
+			return keywords[i] == "type"
+		}
+		return keywords[i] < keywords[j]
+	})
+	for _, keyword := range keywords {
+		value := object[keyword]
+		keywordPath := path + "/" + keyword
+		switch keyword {
+		case "type":
+			types, err := jsonSchemaTypes(value, keywordPath)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, keywordOption("type", value, jsonTypeRule(types)))
+		case "minimum", "maximum":
+			number, ok := value.(json.Number)
+			limit, err := number.Float64()
+			if !ok || err != nil {
+				return nil, newSchemaError(0, fmt.Sprintf("%s at %s should be number", keyword, keywordPath))
+			}
+			if keyword == "minimum" {
+				options = append(options, Min(limit))
+			} else {
+				options = append(options, Max(limit))
+			}
+		case "minLength", "maxLength":
+			number, ok := value.(json.Number)
+			length, err := number.Int64()
+			if !ok || err != nil || length < 0 {
+				return nil, newSchemaError(0, fmt.Sprintf("%s at %s should be non-negative integer", keyword, keywordPath))
+			}
+			if keyword == "minLength" {
+				options = append(options, MinLength(int(length)))
+			} else {
+				options = append(options, MaxLength(int(length)))
+			}
+		case "pattern":
+			patternStr, ok := value.(string)
+			pattern, err := regexp.Compile(patternStr)
+			if !ok || err != nil {
+				return nil, newSchemaError(0, fmt.Sprintf("pattern at %s is invalid", keywordPath))
+			}
+			options = append(options, Pattern(pattern))
+		case "enum":
+			values, ok := value.([]interface{})
+			if !ok {
+				return nil, newSchemaError(0, fmt.Sprintf("enum at %s should be array", keywordPath))
+			}
+			options = append(options, Enum(values...))
+		case "format":
+			format, _ := value.(string)
+			if _, ok := formats[format]; !ok {
+				p.unsupported = append(p.unsupported, fmt.Sprintf("%s (%v)", keywordPath, value))
+				continue
+			}
+			options = append(options, Format(format))
+		case "properties":
+			properties, err := p.object(object, path)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, Properties(properties))
+		case "required":
+			// required is translated with properties
+			if _, ok := object["properties"]; !ok {
+				properties, err := p.object(object, path)
+				if err != nil {
+					return nil, err
+				}
+				options = append(options, Properties(properties))
+			}
+		case "items":
+			item, ok := value.(map[string]interface{})
+			if !ok {
+				return nil, newSchemaError(0, fmt.Sprintf("items at %s should be object", keywordPath))
+			}
+			itemOptions, err := p.field(item, keywordPath)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, Items(itemOptions...))
+		default:
+			if !jsonSchemaAnnotations[keyword] {
+				p.unsupported = append(p.unsupported, keywordPath)
+			}
+		}
+	}
+	return options, nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func jsonSchemaTypes(value interface{}, path string) ([]string, error) {
+	known := map[string]bool{"null": true, "boolean": true, "integer": true, "number": true, "string": true, "array": true, "object": true}
+	var types []string
+	switch value := value.(type) {
+	case string:
+		types = []string{value}
+	case []interface{}:
+		for _, t := range value {
+			typeStr, _ := t.(string)
+			types = append(types, typeStr)
+		}
+	}
+	if len(types) == 0 {
+		return nil, newSchemaError(0, fmt.Sprintf("type at %s should be string or array of string", path))
+	}
+	for _, t := range types {
+		if !known[t] {
+			return nil, newSchemaError(0, fmt.Sprintf("type %s at %s is unknown", t, path))
+		}
+	}
+	return types, nil
+}
+
+// jsonTypeRule check the value is one of json types, the integer is number without fraction
+func jsonTypeRule(types []string) windowRule {
+	return func(param string, val interface{}) error {
+		for _, t := range types {
+			if isJSONType(val, t) {
+				return nil
+			}
+		}
+		return newWrongTypeError(fmt.Sprintf("field %s type is not %s", param, strings.Join(types, " or ")), "type", "param", param, "type", strings.Join(types, " or "))
+	}
+}
+
+func isJSONType(val interface{}, jsonType string) bool {
+	switch jsonType {
+	case "null":
+		return val == nil
+	case "boolean":
+		_, ok := val.(bool)
+		return ok
+	case "integer":
+		number, ok := numberOf(val)
+		return ok && number == math.Trunc(number)
+	case "number":
+		_, ok := numberOf(val)
+		return ok
+	case "string":
+		_, ok := val.(string)
+		return ok
+	case "array":
+		kind := reflect.ValueOf(val).Kind()
+		return kind == reflect.Slice || kind == reflect.Array
+	case "object":
+		_, ok := val.(map[string]interface{})
+		return ok
+	}
+	return false
+}
@@ -0,0 +1,182 @@
+package validator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const webhookSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "title": "order",
+  "type": "object",
+  "required": ["id", "amount", "customer"],
+  "properties": {
+    "id": {"type": "string", "pattern": "^ord_[0-9]+$"},
+    "amount": {"type": "integer", "minimum": 1},
+    "currency": {"type": "string", "enum": ["USD", "TWD"]},
+    "customer": {
+      "type": "object",
+      "required": ["email"],
+      "properties": {
+        "email": {"type": "string", "format": "email"},
+        "name": {"type": "string", "maxLength": 8}
+      }
+    },
+    "tags": {"type": "array", "items": {"type": "string", "maxLength": 3}}
+  }
+}`
+
+func TestFromJSONSchema(t *testing.T) {
+	type testCase struct {
+		body    string
+		errMsgs []string
+		absence []string
+	}
+	schema, err := FromJSONSchema([]byte(webhookSchema))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{"amount", "currency", "customer", "id", "tags"}, schema.Params())
+	cases := []testCase{
+		{
+			body:    `{"id": "ord_1", "amount": 100, "currency": "USD", "customer": {"email": "ken@example.com"}, "tags": ["a", "b"]}`,
+			errMsgs: []string{},
+			absence: []string{"customer.name"},
+		},
+		{
+			body: `{"id": "1", "amount": 1.5, "currency": "JPY", "customer": {"name": "Kenneth Lin"}, "tags": ["a", "long"]}`,
+			errMsgs: []string{
+				"field amount type is not integer",
+				"field currency is not one of [USD TWD]",
+				"field customer.email doesn't exist",
+				"field customer.name is longer than 8",
+				"field id doesn't match pattern ^ord_[0-9]+$",
+				"field tags.1 is longer than 3",
+			},
+			absence: []string{"customer.email"},
+		},
+		{
+			body:    `{"amount": 0, "customer": "ken", "tags": "a"}`,
+			errMsgs: []string{"field amount is less than 1", "field customer type is not object", "field id doesn't exist", "field tags type is not array"},
+			absence: []string{"currency", "id"},
+		},
+	}
+	for _, c := range cases {
+		values := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal([]byte(c.body), &values))
+		errs, absence := schema.Validate(&message{msg: values})
+		errMsgs := []string{}
+		for _, err := range errs {
+			errMsgs = append(errMsgs, err.Error())
+		}
+		assert.Equal(t, c.errMsgs, errMsgs)
+		assert.Equal(t, c.absence, absence)
+	}
+}
+
+func TestFromJSONSchemaNull(t *testing.T) {
+	type testCase struct {
+		body    string
+		errMsgs []string
+		absence []string
+	}
+	schema, err := FromJSONSchema([]byte(`{
+  "type": "object",
+  "properties": {
+    "age": {"minimum": 1},
+    "nick": {"type": ["string", "null"], "maxLength": 3},
+    "tags": {"type": ["array", "null"], "items": {"type": "string"}},
+    "contact": {"type": ["object", "null"], "properties": {"email": {"type": "string"}}},
+    "customer": {
+      "type": "object",
+      "properties": {
+        "note": {"type": ["string", "null"]},
+        "email": {"type": "string"}
+      }
+    }
+  }
+}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	cases := []testCase{
+		{
+			body:    `{"age": 5, "nick": "ken", "tags": null, "contact": null, "customer": {"note": null}}`,
+			errMsgs: []string{},
+			absence: []string{"customer.email"},
+		},
+		{
+			body:    `{"age": "5", "nick": null, "customer": {"note": null, "email": null}}`,
+			errMsgs: []string{"field age type is not number", "field customer.email type is not string"},
+			absence: []string{"contact", "tags"},
+		},
+		{
+			body:    `{"age": null, "nick": 3}`,
+			errMsgs: []string{"field nick type is not string or null"},
+			absence: []string{"contact", "customer", "tags"},
+		},
+	}
+	for _, c := range cases {
+		values := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal([]byte(c.body), &values))
+		errs, absence := schema.Validate(&message{msg: values})
+		errMsgs := []string{}
+		for _, err := range errs {
+			errMsgs = append(errMsgs, err.Error())
+		}
+		assert.Equal(t, c.errMsgs, errMsgs)
+		assert.Equal(t, c.absence, absence)
+	}
+}
+
+func TestFromJSONSchemaError(t *testing.T) {
+	type testCase struct {
+		doc    string
+		errMsg string
+	}
+	cases := []testCase{
+		{
+			doc:    `{"type": "object", "additionalProperties": false, "properties": {"tags": {"type": "array", "uniqueItems": true}, "id": {"oneOf": []}}}`,
+			errMsg: "json schema has unsupported keywords: /additionalProperties, /properties/id/oneOf, /properties/tags/uniqueItems",
+		},
+		{
+			doc:    `{"properties": {"ip": {"type": "string", "format": "ipv5"}}}`,
+			errMsg: "json schema has unsupported keywords: /properties/ip/format (ipv5)",
+		},
+		{
+			doc:    `{"properties": {"id": {"type": "text"}}}`,
+			errMsg: "type text at /properties/id/type is unknown",
+		},
+		{
+			doc:    `{"properties": {"id": {"pattern": "["}}}`,
+			errMsg: "pattern at /properties/id/pattern is invalid",
+		},
+		{
+			doc:    `{"type": "array"}`,
+			errMsg: "type of json schema should be object",
+		},
+		{
+			doc:    "{\n  \"properties\": {\n    \"id\": {\"type\": \"string\",}\n  }\n}",
+			errMsg: "line 3: invalid character '}' looking for beginning of object key string",
+		},
+	}
+	for _, c := range cases {
+		_, err := FromJSONSchema([]byte(c.doc))
+		if assert.Error(t, err) {
+			assert.Equal(t, c.errMsg, err.Error())
+		}
+	}
+}
+
+func TestFromJSONSchemaEmpty(t *testing.T) {
+	schema, err := FromJSONSchema([]byte(`{"type": "object"}`))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []string{}, schema.Params())
+	errs, absence := schema.Validate(&message{msg: map[string]interface{}{"id": "ord_1"}})
+	assert.Equal(t, []error{}, errs)
+	assert.Equal(t, []string{}, absence)
+}
@@ -0,0 +1,235 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var yamlLinePattern = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// schemaTypes map the type names of schema document to the types of field
+var schemaTypes = map[string]FieldOption{
+	"int":      Int(),
+	"int32":    Int32(),
+	"int64":    Int64(),
+	"uint32":   Uint32(),
+	"uint64":   Uint64(),
+	"float64":  Float64(),
+	"bool":     Bool(),
+	"string":   String(),
+	"bytes":    Bytes(),
+	"ip":       IP(),
+	"time":     Time(),
+	"duration": Duration(),
+	"location": Location(),
+	"object":   Object(),
+}
+
+// fieldDoc is the field of schema document
+type fieldDoc struct {
+	name      string
+	fieldType string
+	required  bool
+	min       *float64
+	max       *float64
+	minLength *int
+	maxLength *int
+	pattern   string
+	format    string
+	options   []string
+	// line is the line of field, and lines are the lines of its keys
+	line  int
+	lines map[string]int
+}
+
+// lineOf get the line of key in field, it is the line of field when the key is absent
+func (f *fieldDoc) lineOf(key string) int {
+	if line, ok := f.lines[key]; ok {
+		return line
+	}
+	return f.line
+}
+
+// LoadSchema build schema from document of JSON or YAML, like
+//
+//	fields:
+//	  - name: age
+//	    type: int
+//	    min: 0
+//	    max: 150
+//	  - name: email
+//	    type: string
+//	    required: false
+//	    format: email
+//	    options: [trimspace, lower]
+//
+// The fields are required unless required is false. The keys of field are name, type,
+// required, min, max, minLength, maxLength, pattern, format and options, which are the
+// options of vld tag. The types int32, int64, uint64 and bytes can be checked by Validate, and
+// sanitizing them is ConfigError. The invalid document, like repeated keys and fields, is
+// reported as SchemaError with its line
+func LoadSchema(data []byte) (*Schema, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		line := 0
+		if match := yamlLinePattern.FindStringSubmatch(err.Error()); match != nil {
+			line, _ = strconv.Atoi(match[1])
+		}
+		return nil, newSchemaError(line, yamlLinePattern.ReplaceAllString(err.Error(), ""))
+	}
+	if len(root.Content) == 0 {
+		return nil, newSchemaError(0, "schema is empty")
+	}
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return nil, newSchemaError(doc.Line, "schema should be object")
+	}
+	schema := NewSchema()
+	hasFields := false
+	names := map[string]bool{}
+	for i := 0; i < len(doc.Content); i += 2 {
+		key, value := doc.Content[i], doc.Content[i+1]
+		if key.Value != "fields" {
+			return nil, newSchemaError(key.Line, fmt.Sprintf("key %s is unknown", key.Value))
+		}
+		if hasFields {
+			return nil, newSchemaError(key.Line, "key fields is repeated")
+		}
+		hasFields = true
+		if value.Kind != yaml.SequenceNode {
+			return nil, newSchemaError(value.Line, "fields should be list")
+		}
+		for _, fieldNode := range value.Content {
+			field, err := parseFieldDoc(fieldNode)
+			if err != nil {
+				return nil, err
+			}
+			if names[field.name] {
+				return nil, newSchemaError(field.lineOf("name"), fmt.Sprintf("field %s is repeated", field.name))
+			}
+			names[field.name] = true
+			options, err := field.fieldOptions()
+			if err != nil {
+				return nil, err
+			}
+			schema = schema.Field(field.name, options...)
+		}
+	}
+	return schema, nil
+}
+
+// LoadSchemaFile build schema from JSON or YAML file, see LoadSchema for the document
+func LoadSchemaFile(path string) (*Schema, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LoadSchema(data)
+}
+
+func parseFieldDoc(node *yaml.Node) (*fieldDoc, error) {
+	if node.Kind != yaml.MappingNode {
+		return nil, newSchemaError(node.Line, "field should be object")
+	}
+	field := &fieldDoc{required: true, line: node.Line, lines: map[string]int{}}
+	targets := map[string]interface{}{
+		"name":      &field.name,
+		"type":      &field.fieldType,
+		"required":  &field.required,
+		"min":       &field.min,
+		"max":       &field.max,
+		"minLength": &field.minLength,
+		"maxLength": &field.maxLength,
+		"pattern":   &field.pattern,
+		"format":    &field.format,
+		"options":   &field.options,
+	}
+	for i := 0; i < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		target, ok := targets[key.Value]
+		if !ok {
+			return nil, newSchemaError(key.Line, fmt.Sprintf("key %s of field is unknown", key.Value))
+		}
+		if _, ok := field.lines[key.Value]; ok {
+			return nil, newSchemaError(key.Line, fmt.Sprintf("key %s of field is repeated", key.Value))
+		}
+		field.lines[key.Value] = key.Line
+		if err := value.Decode(target); err != nil {
+			return nil, newSchemaError(value.Line, fmt.Sprintf("value %s of %s is invalid", value.Value, key.Value))
+		}
+	}
+	if field.name == "" {
+		return nil, newSchemaError(node.Line, "field has no name")
+	}
+	return field, nil
+}
+
+// fieldOptions translate the field of document to the options of schema field
+func (f *fieldDoc) fieldOptions() ([]FieldOption, error) {
+	options := []FieldOption{}
+	if f.fieldType != "" {
+		typeOption, ok := schemaTypes[f.fieldType]
+		if !ok {
+			return nil, newSchemaError(f.lineOf("type"), fmt.Sprintf("type %s of %s is unknown, it should be one of %s", f.fieldType, f.name, strings.Join(schemaTypeNames(), ", ")))
+		}
+		options = append(options, typeOption)
+	}
+	if !f.required {
+		options = append(options, Optional())
+	}
+	for _, option := range f.options {
+		if !IsTagOption(option) {
+			return nil, newSchemaError(f.lineOf("options"), fmt.Sprintf("option %s of %s is unknown", option, f.name))
+		}
+		if zone, ok := tagOption([]string{option}, "tz"); ok {
+			if _, err := time.LoadLocation(zone); err != nil {
+				return nil, newSchemaError(f.lineOf("options"), fmt.Sprintf("time zone %s of %s is unknown", zone, f.name))
+			}
+		}
+	}
+	if len(f.options) > 0 {
+		options = append(options, Options(f.options...))
+	}
+	if f.min != nil {
+		options = append(options, Min(*f.min))
+	}
+	if f.max != nil {
+		options = append(options, Max(*f.max))
+	}
+	if f.minLength != nil {
+		options = append(options, MinLength(*f.minLength))
+	}
+	if f.maxLength != nil {
+		options = append(options, MaxLength(*f.maxLength))
+	}
+	if f.pattern != "" {
+		pattern, err := regexp.Compile(f.pattern)
+		if err != nil {
+			return nil, newSchemaError(f.lineOf("pattern"), fmt.Sprintf("pattern %s of %s is invalid", f.pattern, f.name))
+		}
+		options = append(options, Pattern(pattern))
+	}
+	if f.format != "" {
+		if _, ok := formats[f.format]; !ok {
+			return nil, newSchemaError(f.lineOf("format"), fmt.Sprintf("format %s of %s is unknown", f.format, f.name))
+		}
+		options = append(options, Format(f.format))
+	}
+	return options, nil
+}
+
+func schemaTypeNames() []string {
+	names := make([]string, 0, len(schemaTypes))
+	for name := range schemaTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
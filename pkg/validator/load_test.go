@@ -0,0 +1,166 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const yamlSchema = `fields:
+  - name: age
+    type: int
+    min: 0
+    max: 150
+  - name: email
+    type: string
+    required: false
+    format: email
+    options: [trimspace, lower]
+  - name: code
+    type: string
+    pattern: "^[A-Z]{3}$"
+    maxLength: 3
+`
+
+const jsonSchema = `{
+  "fields": [
+    {"name": "age", "type": "int", "min": 0, "max": 150},
+    {"name": "email", "type": "string", "required": false, "format": "email", "options": ["trimspace", "lower"]},
+    {"name": "code", "type": "string", "pattern": "^[A-Z]{3}$", "maxLength": 3}
+  ]
+}`
+
+func TestLoadSchema(t *testing.T) {
+	type testCase struct {
+		dataReq *message
+		out     map[string]interface{}
+		errMsgs []string
+	}
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{"age": "18", "email": " Ken@Example.com ", "code": "ABC"}},
+			out:     map[string]interface{}{"age": 18, "email": "ken@example.com", "code": "ABC"},
+			errMsgs: []string{},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{"age": "200", "email": "ken", "code": "abcd"}},
+			out:     map[string]interface{}{"age": 200, "email": "ken", "code": "abcd"},
+			errMsgs: []string{
+				"field age is greater than 150",
+				"field email is not email",
+				"field code is longer than 3",
+			},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{"age": "18", "code": "abc"}},
+			out:     map[string]interface{}{"age": 18, "code": "abc"},
+			errMsgs: []string{"field code doesn't match pattern ^[A-Z]{3}$"},
+		},
+	}
+	for _, doc := range []string{yamlSchema, jsonSchema} {
+		schema, err := LoadSchema([]byte(doc))
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.Equal(t, []string{"age", "email", "code"}, schema.Params())
+		for _, c := range cases {
+			out := map[string]interface{}{}
+			errs, _ := schema.Sanitize(c.dataReq, out)
+			errMsgs := []string{}
+			for _, err := range errs {
+				errMsgs = append(errMsgs, err.Error())
+			}
+			assert.Equal(t, c.errMsgs, errMsgs)
+			assert.Equal(t, c.out, out)
+		}
+	}
+}
+
+func TestLoadSchemaError(t *testing.T) {
+	type testCase struct {
+		doc    string
+		line   int
+		errMsg string
+	}
+	cases := []testCase{
+		{
+			doc:    "fields:\n  - name: age\n    type: uint8\n",
+			line:   3,
+			errMsg: "line 3: type uint8 of age is unknown, it should be one of bool, bytes, duration, float64, int, int32, int64, ip, location, object, string, time, uint32, uint64",
+		},
+		{
+			doc:    "fields:\n  - name: age\n    type: int\n    min: zero\n",
+			line:   4,
+			errMsg: "line 4: value zero of min is invalid",
+		},
+		{
+			doc:    "fields:\n  - name: age\n    maximum: 3\n",
+			line:   3,
+			errMsg: "line 3: key maximum of field is unknown",
+		},
+		{
+			doc:    "{\n  \"fields\": [\n    {\"name\": \"code\", \"pattern\": \"[\"}\n  ]\n}",
+			line:   3,
+			errMsg: "line 3: pattern [ of code is invalid",
+		},
+		{
+			doc:    "fields:\n  - name: email\n    format: mail\n",
+			line:   3,
+			errMsg: "line 3: format mail of email is unknown",
+		},
+		{
+			doc:    "fields:\n  - name: name\n    options: [trimspcae]\n",
+			line:   3,
+			errMsg: "line 3: option trimspcae of name is unknown",
+		},
+		{
+			doc:    "fields:\n  - type: int\n",
+			line:   2,
+			errMsg: "line 2: field has no name",
+		},
+		{
+			doc:    "field:\n  - name: age\n",
+			line:   1,
+			errMsg: "line 1: key field is unknown",
+		},
+		{
+			doc:    "fields:\n  - name: age\nfields:\n  - name: name\n",
+			line:   3,
+			errMsg: "line 3: key fields is repeated",
+		},
+		{
+			doc:    "fields:\n  - name: age\n    type: int\n  - name: age\n    min: 0\n",
+			line:   4,
+			errMsg: "line 4: field age is repeated",
+		},
+		{
+			doc:    "fields:\n  - name: age\n    min: 0\n    min: 1\n",
+			line:   4,
+			errMsg: "line 4: key min of field is repeated",
+		},
+		{
+			doc:    "fields:\n  - name: age\n   type: int\n",
+			line:   1,
+			errMsg: "line 1: did not find expected '-' indicator",
+		},
+	}
+	for _, c := range cases {
+		_, err := LoadSchema([]byte(c.doc))
+		if assert.Error(t, err) {
+			assert.Equal(t, c.errMsg, err.Error())
+			assert.Equal(t, c.line, err.(SchemaError).Line)
+		}
+	}
+}
+
+func TestLoadSchemaUnsanitizableType(t *testing.T) {
+	schema, err := LoadSchema([]byte("fields:\n  - name: id\n    type: int64\n"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	errs, _ := schema.Validate(&message{msg: map[string]interface{}{"id": int64(1)}})
+	assert.Equal(t, []error{}, errs)
+	errs, _ = schema.Sanitize(&message{msg: map[string]interface{}{"id": "1"}}, map[string]interface{}{})
+	assert.Equal(t, 1, len(errs))
+	assert.IsType(t, ConfigError{}, errs[0])
+}
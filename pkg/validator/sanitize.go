@@ -1,7 +1,6 @@
 package validator
 
 import (
-	"encoding/json"
 	"fmt"
 	"net"
 	"reflect"
@@ -13,8 +12,15 @@ import (
 // SanitizeType is type to sanitize
 type SanitizeType struct {
 	validatorBase
-	cutset     string
-	timeFormat string
+	transforms  []transform
+	timeFormats []string
+	unixUnit    time.Duration
+	location    *time.Location
+	unknownZone string
+	out         interface{}
+	jsonOptions jsonOptions
+	options     []string
+	asVariable  bool
 }
 
 // Sanitize return a sanitize type to following operations
@@ -80,8 +86,14 @@ func (v *SanitizeType) ToObject(out interface{}) *SanitizeType {
 	return v
 }
 
-// ToString sanitize field to string
+// ToString sanitize field to string, the message is assigned as it is
 func (v *SanitizeType) ToString(out interface{}) *SanitizeType {
+	v.toValue(out, stringType)
+	return v
+}
+
+// ToJSONString sanitize field to string, the message should be json string like "\"John\""
+func (v *SanitizeType) ToJSONString(out interface{}) *SanitizeType {
 	v.toValue(out, objectType)
 	return v
 }
@@ -110,9 +122,54 @@ func (v *SanitizeType) ToLocalTime(out interface{}) *SanitizeType {
 	return v
 }
 
+// ToDuration sanitize field to duration, the message can be like "1h30m", seconds like "90",
+// or ISO 8601 duration like "PT1H30M"
+func (v *SanitizeType) ToDuration(out interface{}) *SanitizeType {
+	v.toValue(out, durationType)
+	return v
+}
+
+// ToLocation sanitize field to *time.Location by IANA time zone name, like "Asia/Taipei"
+func (v *SanitizeType) ToLocation(out interface{}) *SanitizeType {
+	v.toValue(out, locationType)
+	return v
+}
+
 // Params tag the param that will be sanitized
 func (v *SanitizeType) Params(param string) *SanitizeType {
-	v.param = param
+	v.setParam(param)
+	return v
+}
+
+// Strict report the params that are not asked by any rule or target struct,
+// the payload should implement ParamLister for it
+func (v *SanitizeType) Strict() *SanitizeType {
+	v.setStrict()
+	return v
+}
+
+// FailFast stop validating at the first error of the session
+func (v *SanitizeType) FailFast() *SanitizeType {
+	v.setFailFast()
+	return v
+}
+
+// WithClock set the clock of session, time-relative rules use it as the reference time
+func (v *SanitizeType) WithClock(clock Clock) *SanitizeType {
+	v.setClock(clock)
+	return v
+}
+
+// Bail skip the following rules of param once one of them fails
+func (v *SanitizeType) Bail() *SanitizeType {
+	v.bail = true
+	return v
+}
+
+// AsVariable tag the out of ToStruct and ToObject is the variable assigned with the whole message,
+// even it is struct with vld tags, like Params("foot").AsVariable().ToStruct(&f)
+func (v *SanitizeType) AsVariable() *SanitizeType {
+	v.asVariable = true
 	return v
 }
 
@@ -124,149 +181,205 @@ func (v *SanitizeType) Optional() *SanitizeType {
 
 // Trim the unused part before assign
 func (v *SanitizeType) Trim(str string) *SanitizeType {
-	v.cutset = str
-	return v
-}
-
-// TimeFormat provide time format info to sanitize
-func (v *SanitizeType) TimeFormat(str string) *SanitizeType {
-	v.timeFormat = str
-	return v
+	return v.transform(func(val string) string {
+		return strings.Trim(val, str)
+	})
 }
 
 func (v *SanitizeType) toValue(out interface{}, dataType int) *SanitizeType {
+	v.out = out
+	if info := structInfoOfTarget(out); info != nil && !v.isStructVariable(out, dataType) {
+		v.cover(info.tags...)
+		v.recordStruct(out)
+	}
+	field, options, err := v.getField(out, dataType)
+	if err != nil {
+		if !v.skip() {
+			v.handleErrors(err)
+		}
+		return v
+	}
+	if len(v.options) > 0 {
+		options = append(append([]string{}, v.options...), options...)
+	}
+	if err := checkOptions(v.getParam(), options); err != nil {
+		if !v.skip() {
+			v.handleErrors(err)
+		}
+		return v
+	}
+	// the optional option of tag, like `vld:"age,optional"`, works like Optional for this field
+	if _, optional := tagOption(options, "optional"); optional && !v.optional {
+		v.optional = true
+		defer func() { v.optional = false }()
+	}
 	val, exist := v.handleAbsence()
 	if exist {
-		if v.cutset != "" {
-			val = strings.Trim(val, v.cutset)
-		}
-		var valInstance interface{}
-		var field = v.getField(out)
-		var err error
-		switch dataType {
-		case intType:
-			valInstance, err = strconv.Atoi(val)
-			if err != nil {
-				err = newWrongTypeError(fmt.Sprintf("message %v is not int", val))
-			} else {
-				setField(field, valInstance)
-			}
-		case uint32Type:
-			var uint32Instance uint64
-			uint32Instance, err = strconv.ParseUint(val, 10, 32)
-			valInstance = uint32(uint32Instance)
-			if err != nil {
-				err = newWrongTypeError(fmt.Sprintf("message %v is not int32", val))
-			} else {
-				setField(field, valInstance)
-			}
-		case float64Type:
-			valInstance, err = strconv.ParseFloat(val, 64)
-			if err != nil {
-				err = newWrongTypeError(fmt.Sprintf("message %v is not float", val))
-			} else {
-				setField(field, valInstance)
-			}
-		case boolType:
-			valInstance, err = strconv.ParseBool(val)
-			if err != nil {
-				err = newWrongTypeError(fmt.Sprintf("message %v is not bool", val))
-			} else {
-				setField(field, valInstance)
-			}
-		case objectType:
-			varAddr := field.Addr().Interface()
-			err = json.Unmarshal([]byte(val), varAddr)
-			if err != nil {
-				err = newWrongTypeError(fmt.Sprintf("message %v is not json or string", val))
-			}
-		case ipType:
-			valInstance = net.ParseIP(val)
-			if valInstance == nil {
-				err = newWrongTypeError(fmt.Sprintf("message %v is not ip", val))
-			} else {
-				setField(field, valInstance)
-			}
-		case timeType:
-			valInstance, err = time.Parse(v.timeFormat, val)
-			if err != nil {
-				err = newWrongTypeError(fmt.Sprintf("message %v is not time. parse error: %v", val, err.Error()))
-			} else {
-				setField(field, valInstance)
-			}
-		case localTimeType:
-			valInstance, err = time.ParseInLocation(v.timeFormat, val, time.Local)
-			if err != nil {
-				err = newWrongTypeError(fmt.Sprintf("message %v is not time. parse error: %v", val, err.Error()))
-			} else {
-				setField(field, valInstance)
+		if dataType == objectType {
+			err = v.decodeJSON(v.transformJSON(val, options, field), field)
+		} else {
+			var valInstance interface{}
+			valInstance, err = v.convert(v.applyTransforms(val, options), dataType, options)
+			if err == nil {
+				err = v.setField(field, valInstance)
 			}
 		}
+		if err == nil {
+			setMapTarget(out, v.param, field)
+			v.markSanitized(v.getParam())
+		}
 		v.handleErrors(err)
 	}
 	return v
 }
 
+// convert the transformed message to the value of data type, object type is decoded by decodeJSON
+func (v *SanitizeType) convert(val string, dataType int, options []string) (interface{}, error) {
+	var valInstance interface{}
+	var err error
+	switch dataType {
+	case intType:
+		valInstance, err = strconv.Atoi(val)
+		if err != nil {
+			err = newTypeError(v.getParam(), "int", "value", val)
+		}
+	case uint32Type:
+		var uint32Instance uint64
+		uint32Instance, err = strconv.ParseUint(val, 10, 32)
+		valInstance = uint32(uint32Instance)
+		if err != nil {
+			err = newTypeError(v.getParam(), "uint32", "value", val)
+		}
+	case float64Type:
+		valInstance, err = strconv.ParseFloat(val, 64)
+		if err != nil {
+			err = newTypeError(v.getParam(), "float64", "value", val)
+		}
+	case boolType:
+		valInstance, err = strconv.ParseBool(val)
+		if err != nil {
+			err = newTypeError(v.getParam(), "bool", "value", val)
+		}
+	case stringType:
+		valInstance = val
+		if object, ok := v.content.(*objectPayload); ok && !object.isString(v.getParam()) {
+			err = newTypeError(v.getParam(), "string", "value", val)
+		}
+	case ipType:
+		ip := net.ParseIP(val)
+		if ip == nil {
+			err = newTypeError(v.getParam(), "ip", "value", val)
+		}
+		valInstance = ip
+	case timeType:
+		valInstance, err = v.toTime(val, false, options)
+	case localTimeType:
+		valInstance, err = v.toTime(val, true, options)
+	case durationType:
+		valInstance, err = parseDuration(val)
+		if err != nil {
+			err = newTypeError(v.getParam(), "duration", "value", val)
+		}
+	case locationType:
+		valInstance, err = time.LoadLocation(val)
+		if err != nil {
+			err = newTypeError(v.getParam(), "time zone", "value", val)
+		}
+	default:
+		// int32, int64, uint64 and bytes can be checked but not sanitized
+		err = newConfigError(fmt.Sprintf("type of %s can be checked but not sanitized", v.getParam()), "param", v.getParam())
+	}
+	return valInstance, err
+}
+
 func (v *SanitizeType) handleAbsence() (string, bool) {
 	val, exist := handleAbsence(v)
 	valStr, ok := val.(string)
 	return valStr, (exist && ok)
 }
 
-func (v *SanitizeType) handleErrors(err error) {
-	cache := v.content.GetCache()
-	var errorList []error
-	if cache[contextKey].(map[string]interface{})[errorsKey] != nil {
-		errorList = cache[contextKey].(map[string]interface{})[errorsKey].([]error)
+// getField get where the sanitized value is assigned to. The out can be pointer to struct with
+// vld tags, pointer to variable like *int, or map keyed by param name. For map target, the
+// returned field is a temporary value which is put into map by setMapTarget
+func (v *SanitizeType) getField(out interface{}, dataType int) (reflect.Value, []string, error) {
+	targetValue := reflect.ValueOf(out)
+	if isMapTarget(targetValue) {
+		if targetValue.IsNil() {
+			return reflect.Value{}, nil, newConfigError(fmt.Sprintf("target map of %s is nil", v.param), "param", v.getParam())
+		}
+		return reflect.New(targetValue.Type().Elem()).Elem(), nil, nil
 	}
-	if errorList == nil {
-		errorList = []error{}
-		cache[contextKey].(map[string]interface{})[errorsKey] = errorList
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() {
+		return reflect.Value{}, nil, newConfigError(fmt.Sprintf("target of %s should be pointer or map, not %T", v.param, out), "param", v.getParam())
 	}
-	if err != nil {
-		errorList = append(errorList, err)
-		cache[contextKey].(map[string]interface{})[errorsKey] = errorList
+	elem := targetValue.Elem()
+	switch {
+	case isMapTarget(elem):
+		if elem.IsNil() {
+			elem.Set(reflect.MakeMap(elem.Type()))
+		}
+		return reflect.New(elem.Type().Elem()).Elem(), nil, nil
+	case elem.Kind() == reflect.Struct && elem.Type() != timeReflectType && len(structInfoOf(elem.Type()).tags) > 0 &&
+		!v.isStructVariable(out, dataType):
+		return fieldByTag(out, v.param)
 	}
+	return elem, nil, nil
 }
 
-func (v *SanitizeType) getField(out interface{}) reflect.Value {
-	targetValue := reflect.ValueOf(out).Elem()
-	targetType := reflect.TypeOf(out).Elem()
-	for i := 0; i < targetType.NumField(); i++ {
-		if targetType.Field(i).Tag.Get("vld") == v.param {
-			return targetValue.Field(i)
-		}
+// isStructVariable report out is pointer to struct with vld tags, and it is sanitized by ToStruct
+// or ToObject after AsVariable, so the struct is the variable assigned with message instead of the
+// target of its field. Without AsVariable, the struct without field tagged param is ConfigError
+func (v *SanitizeType) isStructVariable(out interface{}, dataType int) bool {
+	info := structInfoOfTarget(out)
+	return v.asVariable && dataType == objectType && info != nil && len(info.tags) > 0
+}
+
+func isMapTarget(target reflect.Value) bool {
+	return target.Kind() == reflect.Map && target.Type().Key().Kind() == reflect.String
+}
+
+// setMapTarget put the sanitized value into map target with key of param
+func setMapTarget(out interface{}, param string, field reflect.Value) {
+	targetValue := reflect.ValueOf(out)
+	if targetValue.Kind() == reflect.Ptr {
+		targetValue = targetValue.Elem()
 	}
-	return reflect.Value{}
+	if isMapTarget(targetValue) {
+		targetValue.SetMapIndex(reflect.ValueOf(param).Convert(targetValue.Type().Key()), field)
+	}
+}
+
+// fieldByTag get the field tagged with param, the out should be pointer to struct
+func fieldByTag(out interface{}, param string) (reflect.Value, []string, error) {
+	targetValue := reflect.ValueOf(out)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() || targetValue.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, nil, newConfigError(fmt.Sprintf("target of %s should be pointer to struct, not %T", param, out), "param", param)
+	}
+	field, ok := structInfoOf(targetValue.Elem().Type()).byParam[param]
+	if !ok {
+		return reflect.Value{}, nil, newConfigError(fmt.Sprintf("target %T has no field tagged %s", out, param), "param", param)
+	}
+	if !field.exported {
+		return reflect.Value{}, nil, newConfigError(fmt.Sprintf("field %s of %T tagged %s is unexported", field.name, out, param), "param", param)
+	}
+	return targetValue.Elem().FieldByIndex(field.index), field.options, nil
 }
 
 func (v *SanitizeType) getAbsenceError() error {
-	return newNotExistError(v.param + " don't exist!")
-}
-
-func setField(field reflect.Value, val interface{}) {
-	if field.Kind() == reflect.Ptr {
-		switch val.(type) {
-		case int:
-			val := val.(int)
-			field.Set(reflect.ValueOf(&val))
-		case uint32:
-			val := val.(uint32)
-			field.Set(reflect.ValueOf(&val))
-		case float64:
-			val := val.(float64)
-			field.Set(reflect.ValueOf(&val))
-		case bool:
-			val := val.(bool)
-			field.Set(reflect.ValueOf(&val))
-		case time.Time:
-			val := val.(time.Time)
-			field.Set(reflect.ValueOf(&val))
-		case net.IP:
-			val := val.(net.IP)
-			field.Set(reflect.ValueOf(&val))
-		}
-	} else {
-		field.Set(reflect.ValueOf(val))
+	return newRequiredError(v.getParam())
+}
+
+// setField assign the sanitized value to field, the pointer field is assigned with pointer to value
+func (v *SanitizeType) setField(field reflect.Value, val interface{}) error {
+	value := reflect.ValueOf(val)
+	if field.Kind() == reflect.Ptr && !value.Type().AssignableTo(field.Type()) {
+		ptr := reflect.New(value.Type())
+		ptr.Elem().Set(value)
+		value = ptr
+	}
+	if !value.Type().AssignableTo(field.Type()) {
+		return newConfigError(fmt.Sprintf("target of %s in %T is %v, it can't be assigned with %T", v.param, v.out, field.Type(), val), "param", v.getParam())
 	}
+	field.Set(value)
+	return nil
 }
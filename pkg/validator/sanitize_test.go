@@ -77,24 +77,32 @@ func TestSanitizeString(t *testing.T) {
 		want      testStruct
 	}
 	strPtr := "hello"
+	quoted := `"hello"`
 	cases := []testCase{
 		{
 			dataReq: &message{msg: map[string]interface{}{
-				"desc": `"hello"`,
+				"desc": "hello",
 			}},
 			dataField: "desc",
 			want:      testStruct{Description: "hello"},
 		},
 		{
 			dataReq: &message{msg: map[string]interface{}{
-				"strptr": `"hello"`,
+				"strptr": "hello",
 			}},
 			dataField: "strptr",
 			want:      testStruct{StrPtr: &strPtr},
 		},
 		{
 			dataReq: &message{msg: map[string]interface{}{
-				"null": `"hello"`,
+				"strptr": `"hello"`,
+			}},
+			dataField: "strptr",
+			want:      testStruct{StrPtr: &quoted},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"null": "hello",
 			}},
 			dataField: "strptr",
 			want:      testStruct{StrPtr: nil},
@@ -107,6 +115,47 @@ func TestSanitizeString(t *testing.T) {
 	}
 }
 
+func TestSanitizeJSONString(t *testing.T) {
+	type testCase struct {
+		dataReq         *message
+		dataField       string
+		want            testStruct
+		wantFormatError int
+	}
+	strPtr := "hello"
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"desc": `"hello"`,
+			}},
+			dataField: "desc",
+			want:      testStruct{Description: "hello"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"strptr": `"hello"`,
+			}},
+			dataField: "strptr",
+			want:      testStruct{StrPtr: &strPtr},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"desc": "hello",
+			}},
+			dataField:       "desc",
+			want:            testStruct{},
+			wantFormatError: 1,
+		},
+	}
+	for _, tc := range cases {
+		actual := testStruct{}
+		Sanitize(tc.dataReq).Params(tc.dataField).ToJSONString(&actual)
+		errs, _ := ValidateResult(tc.dataReq)
+		assert.Equal(t, tc.wantFormatError, len(errs))
+		assert.Equal(t, tc.want, actual)
+	}
+}
+
 func TestSanitizeObject(t *testing.T) {
 	type testCase struct {
 		dataReq   *message
@@ -304,3 +353,207 @@ func TestSanitizeTime(t *testing.T) {
 		assert.Equal(t, tc.want, actual)
 	}
 }
+
+func TestSanitizeConfigError(t *testing.T) {
+	type testCase struct {
+		out     interface{}
+		dataReq *message
+		wantMsg string
+	}
+	var nilStruct *testStruct
+	cases := []testCase{
+		{
+			out:     testStruct{},
+			wantMsg: "pointer or map",
+		},
+		{
+			out:     nilStruct,
+			wantMsg: "pointer or map",
+		},
+		{
+			out:     nil,
+			wantMsg: "pointer or map",
+		},
+		{
+			out:     &[]int{},
+			wantMsg: "can't be assigned with int",
+		},
+		{
+			out:     map[string]bool(nil),
+			wantMsg: "map of age is nil",
+		},
+		{
+			out:     map[string]bool{},
+			wantMsg: "can't be assigned with int",
+		},
+		{
+			out:     &body{},
+			wantMsg: "validator.body has no field tagged age",
+		},
+		{
+			out: &struct {
+				age int `vld:"age"`
+			}{},
+			wantMsg: "unexported",
+		},
+		{
+			out: &struct {
+				Age bool `vld:"age"`
+			}{},
+			wantMsg: "can't be assigned with int",
+		},
+		{
+			out: &struct {
+				Age *bool `vld:"age"`
+			}{},
+			wantMsg: "can't be assigned with int",
+		},
+	}
+	for _, tc := range cases {
+		payload := &message{msg: map[string]interface{}{
+			"age": "18",
+		}}
+		assert.NotPanics(t, func() {
+			Sanitize(payload).Params("age").ToInt(tc.out)
+		})
+		errs, _ := ValidateResult(payload)
+		assert.Equal(t, 1, len(errs))
+		_, ok := errs[0].(ConfigError)
+		assert.True(t, ok)
+		assert.Contains(t, errs[0].Error(), tc.wantMsg)
+	}
+}
+
+func TestSanitizeConfigErrorAbsence(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{}}
+	Sanitize(payload).Optional().Params("age").ToInt(&body{})
+	errs, absence := ValidateResult(payload)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, 0, len(absence))
+}
+
+func TestSanitizeVariable(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"limit":   "10",
+		"name":    "ken",
+		"alive":   "true",
+		"start":   "2020-11-06T16:19:23Z",
+		"leg":     `{"number": 2}`,
+		"parent":  `["Mary", "Peter"]`,
+		"address": "127.0.0.1",
+	}}
+	var limit int
+	var limitPtr *int
+	var name string
+	var alive bool
+	var start time.Time
+	var legs leg
+	var parent []string
+	var address net.IP
+	Sanitize(payload).Params("limit").ToInt(&limit).ToInt(&limitPtr)
+	Sanitize(payload).Params("name").ToString(&name)
+	Sanitize(payload).Params("alive").ToBool(&alive)
+	Sanitize(payload).Params("start").ToTime(&start)
+	Sanitize(payload).Params("leg").ToStruct(&legs)
+	Sanitize(payload).Params("parent").ToStruct(&parent)
+	Sanitize(payload).Params("address").ToIP(&address)
+	errs, _ := ValidateResult(payload)
+	assert.Equal(t, 0, len(errs))
+	assert.Equal(t, 10, limit)
+	assert.Equal(t, 10, *limitPtr)
+	assert.Equal(t, "ken", name)
+	assert.Equal(t, true, alive)
+	assert.Equal(t, time.Date(2020, 11, 6, 16, 19, 23, 0, time.UTC), start)
+	assert.Equal(t, leg{Number: 2}, legs)
+	assert.Equal(t, []string{"Mary", "Peter"}, parent)
+	assert.Equal(t, net.IPv4(127, 0, 0, 1), address)
+}
+
+func TestSanitizeTaggedStructVariable(t *testing.T) {
+	payload := &listedMessage{message{msg: map[string]interface{}{
+		"foot": `{"size": "42A", "color": "red", "bought": "2020-11-06T16:19:23+08:00", "comment": "nice"}`,
+	}}}
+	var f foot
+	var stayPtr *stay
+	Sanitize(payload).Strict().Params("foot").AsVariable().ToStruct(&f)
+	Sanitize(payload).Params("stay").AsVariable().ToStruct(&stayPtr)
+	errs, absence := ValidateResult(payload)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "field foot.size type is not int", errs[0].Error())
+	assert.Equal(t, "field stay doesn't exist", errs[1].Error())
+	assert.Equal(t, []string{"foot.shop", "foot.bigToe", "stay"}, absence)
+	assert.Equal(t, "red", f.Color)
+	assert.Equal(t, "nice", f.Comment)
+	assert.Nil(t, stayPtr)
+}
+
+func TestSanitizeUntaggedParam(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"foot": `{"size": 42, "color": "red", "bought": "2020-11-06T16:19:23+08:00"}`,
+	}}
+	b := body{}
+	Sanitize(payload).Params("fot").ToStruct(&b)
+	errs, absence := ValidateResult(payload)
+	assert.Equal(t, 1, len(errs))
+	assert.IsType(t, ConfigError{}, errs[0])
+	assert.Equal(t, "target *validator.body has no field tagged fot", errs[0].Error())
+	assert.Equal(t, []string{}, absence)
+	assert.Equal(t, body{}, b)
+}
+
+func TestSanitizeOptionalTag(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{}}
+	f := foot{}
+	Sanitize(payload).Params("color").ToString(&f).Params("size").ToInt(&f)
+	errs, absence := ValidateResult(payload)
+	assert.Equal(t, 1, len(errs))
+	assert.EqualError(t, errs[0], "field size doesn't exist")
+	assert.Equal(t, []string{"color", "size"}, absence)
+}
+
+func TestSanitizeMap(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"age":   "18",
+		"name":  "ken",
+		"hand":  `{"finger": 5}`,
+		"score": "60A",
+	}}
+	actual := map[string]interface{}{}
+	Sanitize(payload).Params("score").ToInt(actual)
+	Sanitize(payload).Params("age").ToInt(actual).LessThan("score")
+	Sanitize(payload).Params("name").ToString(actual)
+	Sanitize(payload).Params("hand").ToObject(actual)
+	errs, _ := ValidateResult(payload)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, map[string]interface{}{
+		"age":  18,
+		"name": "ken",
+		"hand": map[string]interface{}{"finger": float64(5)},
+	}, actual)
+
+	var ages map[string]int
+	Sanitize(payload).Params("age").ToInt(&ages)
+	Sanitize(payload).Params("score").Trim("A").ToInt(&ages).EqualTo("age")
+	errs, _ = ValidateResult(payload)
+	assert.Equal(t, 1, len(errs))
+	_, ok := errs[0].(InvalidValueError)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]int{"age": 18, "score": 60}, ages)
+}
+
+func TestSanitizeEmbedded(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"id":      "7",
+		"orderId": "ord_7",
+		"owner":   `{"foot": {"size": 42, "bought": "2020-11-06T16:19:23Z"}}`,
+	}}
+	actual := order{}
+	Sanitize(payload).Params("id").ToInt(&actual)
+	Sanitize(payload).Params("orderId").ToString(&actual)
+	Sanitize(payload).Params("owner").ToStruct(&actual)
+	errs, _ := ValidateResult(payload)
+	assert.Equal(t, 0, len(errs))
+	assert.Equal(t, 7, actual.auditFields.ID)
+	assert.Equal(t, "ord_7", actual.ID)
+	assert.Equal(t, 42, actual.Owner.Foot.Size)
+}
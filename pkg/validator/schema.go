@@ -0,0 +1,528 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var (
+	emailPattern    = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	uuidPattern     = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hostnamePattern = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+)
+
+// formats are the string formats can be checked by Format
+var formats = map[string]func(val string) bool{
+	"email":    emailPattern.MatchString,
+	"uuid":     uuidPattern.MatchString,
+	"hostname": hostnamePattern.MatchString,
+	"uri": func(val string) bool {
+		u, err := url.Parse(val)
+		return err == nil && u.Scheme != ""
+	},
+	"ipv4": func(val string) bool {
+		ip := net.ParseIP(val)
+		return ip != nil && ip.To4() != nil
+	},
+	"ipv6": func(val string) bool {
+		ip := net.ParseIP(val)
+		return ip != nil && ip.To4() == nil
+	},
+	"date": func(val string) bool {
+		_, err := time.Parse("2006-01-02", val)
+		return err == nil
+	},
+	"date-time": func(val string) bool {
+		_, err := time.Parse(time.RFC3339, val)
+		return err == nil
+	},
+}
+
+// Schema is the rules of params, it is built once and applied to payloads by Validate and
+// Sanitize. Field returns a new schema, so a built schema is never changed and it is safe
+// for concurrent use.
+//
+//	schema := validator.NewSchema().
+//		Field("age", validator.Int(), validator.Min(0)).
+//		Field("name", validator.String(), validator.Options("trimspace"))
+type Schema struct {
+	fields []schemaField
+}
+
+// schemaField is the rules of a param in schema
+type schemaField struct {
+	param    string
+	dataType int
+	optional bool
+	options  []string
+	rules    []windowRule
+	// keywords are the JSON Schema keywords of rules, like "minimum"
+	keywords map[string]interface{}
+	// properties is the schema of object, and items is the field of array elements
+	properties *Schema
+	items      *schemaField
+}
+
+// FieldOption declare the type and rules of the field of schema
+type FieldOption func(field *schemaField)
+
+// NewSchema return an empty schema
+func NewSchema() *Schema {
+	return &Schema{}
+}
+
+// Field return a schema with the field of param added, the param is checked to be exist
+// unless Optional is declared. The rules like Min and Pattern skip null value, it is checked
+// by the type of field
+func (s *Schema) Field(param string, options ...FieldOption) *Schema {
+	field := schemaField{param: param, dataType: objectType}
+	for _, option := range options {
+		option(&field)
+	}
+	fields := make([]schemaField, len(s.fields), len(s.fields)+1)
+	copy(fields, s.fields)
+	return &Schema{fields: append(fields, field)}
+}
+
+// Params get the params of schema in order
+func (s *Schema) Params() []string {
+	params := make([]string, len(s.fields))
+	for i, field := range s.fields {
+		params[i] = field.param
+	}
+	return params
+}
+
+// Validate check the params of payload like CheckType, and return the result like ValidateResult
+func (s *Schema) Validate(payload Payload) ([]error, []string) {
+	Check(payload)
+	s.validate(payload, "")
+	return ValidateResult(payload)
+}
+
+func (s *Schema) validate(payload Payload, prefix string) {
+	for _, field := range s.fields {
+		check := Check(payload).Params(field.param).Bail()
+		check.prefix = prefix
+		check.optional = field.optional
+		check.isType(field.dataType)
+		for _, rule := range field.rules {
+			check.windowRule(rule)
+		}
+		if val, exist := payload.GetParam(check.getParam()); exist && val != nil && !check.skip() {
+			field.validateNested(&check.validatorBase, val)
+		}
+	}
+}
+
+// Sanitize sanitize the params of payload into out like SanitizeType, and return the result like
+// ValidateResult. The out can be pointer to struct with vld tags or map keyed by param
+func (s *Schema) Sanitize(payload Payload, out interface{}) ([]error, []string) {
+	Sanitize(payload)
+	s.sanitize(payload, "", out)
+	return ValidateResult(payload)
+}
+
+func (s *Schema) sanitize(payload Payload, prefix string, out interface{}) {
+	for _, field := range s.fields {
+		session := Sanitize(payload).Params(field.param).Bail()
+		session.prefix = prefix
+		session.optional = field.optional
+		session.options = field.options
+		session.toValue(out, field.dataType)
+		for _, rule := range field.rules {
+			session.windowRule(rule)
+		}
+		if val, exist := payload.GetParam(session.getParam()); exist && !session.skip() {
+			if msg, ok := val.(string); ok && msg != "null" {
+				field.sanitizeNested(session, msg)
+			}
+		}
+	}
+}
+
+// validateNested check the properties of object or the items of array by nested schema, the result
+// is merged into the session with the path of param, like "leg.number" and "tags.0"
+func (f *schemaField) validateNested(v *validatorBase, val interface{}) {
+	var nested *Schema
+	values := map[string]interface{}{}
+	switch {
+	case f.properties != nil:
+		object, ok := val.(map[string]interface{})
+		if !ok {
+			v.handleErrors(newTypeError(v.getParam(), "object"))
+			return
+		}
+		nested, values = f.properties, object
+	case f.items != nil:
+		array := reflect.ValueOf(val)
+		if array.Kind() != reflect.Slice && array.Kind() != reflect.Array {
+			v.handleErrors(newTypeError(v.getParam(), "array"))
+			return
+		}
+		nested = &Schema{}
+		for i := 0; i < array.Len(); i++ {
+			item := f.itemField(i)
+			nested.fields = append(nested.fields, item)
+			values[item.param] = array.Index(i).Interface()
+		}
+	default:
+		return
+	}
+	nestedPayload := &mapPayload{prefix: v.getParam() + ".", values: values}
+	Check(nestedPayload)
+	v.inheritSession(nestedPayload)
+	nested.validate(nestedPayload, nestedPayload.prefix)
+	v.mergeResult(ValidateResult(nestedPayload))
+}
+
+// sanitizeNested sanitize the properties of json object or the items of json array in message by
+// nested schema, like the fields of top-level. The sanitized values are only checked by the rules,
+// the out of session is assigned by decoding the whole message
+func (f *schemaField) sanitizeNested(v *SanitizeType, msg string) {
+	var nested *Schema
+	values := map[string]json.RawMessage{}
+	switch {
+	case f.properties != nil:
+		if err := json.Unmarshal([]byte(msg), &values); err != nil {
+			v.handleErrors(newTypeError(v.getParam(), "object", "value", msg))
+			return
+		}
+		nested = f.properties
+	case f.items != nil:
+		var array []json.RawMessage
+		if err := json.Unmarshal([]byte(msg), &array); err != nil {
+			v.handleErrors(newTypeError(v.getParam(), "array", "value", msg))
+			return
+		}
+		nested = &Schema{}
+		for i, raw := range array {
+			item := f.itemField(i)
+			nested.fields = append(nested.fields, item)
+			values[item.param] = raw
+		}
+	default:
+		return
+	}
+	nestedPayload := &objectPayload{prefix: v.getParam() + ".", values: values}
+	Sanitize(nestedPayload)
+	v.inheritSession(nestedPayload)
+	nested.sanitize(nestedPayload, nestedPayload.prefix, map[string]interface{}{})
+	v.mergeResult(ValidateResult(nestedPayload))
+}
+
+// itemField get the field of the i-th item of array
+func (f *schemaField) itemField(i int) schemaField {
+	item := *f.items
+	item.param = strconv.Itoa(i)
+	return item
+}
+
+// mapPayload is the payload of decoded object, the nil value is treated as absence
+type mapPayload struct {
+	prefix string
+	values map[string]interface{}
+	cache  map[string]interface{}
+}
+
+func (p *mapPayload) GetCache() map[string]interface{} {
+	if p.cache == nil {
+		p.cache = make(map[string]interface{})
+	}
+	return p.cache
+}
+
+func (p *mapPayload) SetCache(input map[string]interface{}) {
+	p.cache = input
+}
+
+// GetParam get value of key, the null value is kept as nil instead of absence, so it can be
+// checked by the type of field
+func (p *mapPayload) GetParam(field string) (interface{}, bool) {
+	val, ok := p.values[strings.TrimPrefix(field, p.prefix)]
+	return val, ok
+}
+
+// Int declare the field is int
+func Int() FieldOption {
+	return dataTypeOption(intType)
+}
+
+// Int32 declare the field is int32, it can be checked, and sanitizing it is ConfigError
+func Int32() FieldOption {
+	return dataTypeOption(int32Type)
+}
+
+// Int64 declare the field is int64, it can be checked, and sanitizing it is ConfigError
+func Int64() FieldOption {
+	return dataTypeOption(int64Type)
+}
+
+// Uint32 declare the field is uint32
+func Uint32() FieldOption {
+	return dataTypeOption(uint32Type)
+}
+
+// Uint64 declare the field is uint64, it can be checked, and sanitizing it is ConfigError
+func Uint64() FieldOption {
+	return dataTypeOption(uint64Type)
+}
+
+// Float64 declare the field is float64
+func Float64() FieldOption {
+	return dataTypeOption(float64Type)
+}
+
+// Bool declare the field is bool
+func Bool() FieldOption {
+	return dataTypeOption(boolType)
+}
+
+// String declare the field is string
+func String() FieldOption {
+	return dataTypeOption(stringType)
+}
+
+// Bytes declare the field is bytes, it can be checked, and sanitizing it is ConfigError
+func Bytes() FieldOption {
+	return dataTypeOption(bytesType)
+}
+
+// IP declare the field is ip
+func IP() FieldOption {
+	return dataTypeOption(ipType)
+}
+
+// Time declare the field is time
+func Time() FieldOption {
+	return dataTypeOption(timeType)
+}
+
+// Duration declare the field is duration
+func Duration() FieldOption {
+	return dataTypeOption(durationType)
+}
+
+// Location declare the field is *time.Location
+func Location() FieldOption {
+	return dataTypeOption(locationType)
+}
+
+// Object declare the field is json object or any type, it is the default type of field
+func Object() FieldOption {
+	return dataTypeOption(objectType)
+}
+
+func dataTypeOption(dataType int) FieldOption {
+	return func(field *schemaField) {
+		field.dataType = dataType
+	}
+}
+
+// Optional declare the field is optional
+func Optional() FieldOption {
+	return func(field *schemaField) {
+		field.optional = true
+	}
+}
+
+// Options declare the options of vld tag for sanitizing, like "trimspace" and "tz=Asia/Taipei",
+// the unknown or malformed option is ConfigError of Sanitize
+func Options(options ...string) FieldOption {
+	return func(field *schemaField) {
+		field.options = append(field.options, options...)
+	}
+}
+
+// Min check the field is number not less than min, the string like "5" is not number
+func Min(min float64) FieldOption {
+	return keywordOption("minimum", min, func(param string, val interface{}) error {
+		if val == nil {
+			return nil
+		}
+		num, ok := numberOf(val)
+		if !ok {
+			return newTypeError(param, "number")
+		}
+		if num < min {
+			return newInvalidValueError(fmt.Sprintf("field %s is less than %v", param, min), "min", "param", param, "limit", min)
+		}
+		return nil
+	})
+}
+
+// Max check the field is number not greater than max, the string like "5" is not number
+func Max(max float64) FieldOption {
+	return keywordOption("maximum", max, func(param string, val interface{}) error {
+		if val == nil {
+			return nil
+		}
+		num, ok := numberOf(val)
+		if !ok {
+			return newTypeError(param, "number")
+		}
+		if num > max {
+			return newInvalidValueError(fmt.Sprintf("field %s is greater than %v", param, max), "max", "param", param, "limit", max)
+		}
+		return nil
+	})
+}
+
+// MinLength check the field is string, bytes, slice or map whose length is not less than min,
+// the length of string is counted in characters
+func MinLength(min int) FieldOption {
+	return keywordOption("minLength", min, func(param string, val interface{}) error {
+		if val == nil {
+			return nil
+		}
+		length, ok := lengthOf(val)
+		if !ok {
+			return newWrongTypeError(fmt.Sprintf("field %s has no length", param), "type.sized", "param", param)
+		}
+		if length < min {
+			return newInvalidValueError(fmt.Sprintf("field %s is shorter than %d", param, min), "min_length", "param", param, "limit", min)
+		}
+		return nil
+	})
+}
+
+// MaxLength check the field is string, bytes, slice or map whose length is not greater than max,
+// the length of string is counted in characters
+func MaxLength(max int) FieldOption {
+	return keywordOption("maxLength", max, func(param string, val interface{}) error {
+		if val == nil {
+			return nil
+		}
+		length, ok := lengthOf(val)
+		if !ok {
+			return newWrongTypeError(fmt.Sprintf("field %s has no length", param), "type.sized", "param", param)
+		}
+		if length > max {
+			return newInvalidValueError(fmt.Sprintf("field %s is longer than %d", param, max), "max_length", "param", param, "limit", max)
+		}
+		return nil
+	})
+}
+
+// Pattern check the field is string matched by pattern
+func Pattern(pattern *regexp.Regexp) FieldOption {
+	return keywordOption("pattern", pattern.String(), func(param string, val interface{}) error {
+		if val == nil {
+			return nil
+		}
+		str, ok := val.(string)
+		if !ok {
+			return newTypeError(param, "string")
+		}
+		if !pattern.MatchString(str) {
+			return newInvalidValueError(fmt.Sprintf("field %s doesn't match pattern %s", param, pattern), "pattern", "param", param, "pattern", pattern)
+		}
+		return nil
+	})
+}
+
+// Format check the field is string of format, the formats are email, uri, uuid, hostname,
+// ipv4, ipv6, date and date-time. The unknown format is reported as ConfigError
+func Format(format string) FieldOption {
+	isFormat, known := formats[format]
+	return keywordOption("format", format, func(param string, val interface{}) error {
+		if !known {
+			return newConfigError(fmt.Sprintf("format %s of %s is unknown", format, param), "param", param)
+		}
+		if val == nil {
+			return nil
+		}
+		str, ok := val.(string)
+		if !ok {
+			return newTypeError(param, "string")
+		}
+		if !isFormat(str) {
+			return newInvalidValueError(fmt.Sprintf("field %s is not %s", param, format), "format", "param", param, "format", format)
+		}
+		return nil
+	})
+}
+
+// Enum check the field is one of values, the numbers are compared by value, like 1 and 1.0
+func Enum(values ...interface{}) FieldOption {
+	return keywordOption("enum", values, func(param string, val interface{}) error {
+		for _, value := range values {
+			if equalValue(val, value) {
+				return nil
+			}
+		}
+		return newInvalidValueError(fmt.Sprintf("field %s is not one of %v", param, values), "enum", "param", param, "values", values)
+	})
+}
+
+// Properties check the field is object whose properties are checked by schema, the object is
+// map[string]interface{} like the value decoded from json in Validate, and json object message in
+// Sanitize whose properties are sanitized by schema
+func Properties(schema *Schema) FieldOption {
+	return func(field *schemaField) {
+		field.properties = schema
+	}
+}
+
+// Items check the field is array whose items are checked by options, like Items(String(), MaxLength(8))
+func Items(options ...FieldOption) FieldOption {
+	return func(field *schemaField) {
+		item := &schemaField{dataType: objectType}
+		for _, option := range options {
+			option(item)
+		}
+		field.items = item
+	}
+}
+
+func equalValue(val, value interface{}) bool {
+	if reflect.DeepEqual(val, value) {
+		return true
+	}
+	valNum, ok := numberOf(val)
+	valueNum, valueOk := numberOf(value)
+	return ok && valueOk && valNum == valueNum
+}
+
+// numberOf get the float of number, the string is not number
+func numberOf(val interface{}) (float64, bool) {
+	if number, ok := val.(json.Number); ok {
+		f, err := number.Float64()
+		return f, err == nil
+	}
+	if _, ok := val.(string); ok {
+		return 0, false
+	}
+	return toFloat(val)
+}
+
+func lengthOf(val interface{}) (int, bool) {
+	if str, ok := val.(string); ok {
+		return utf8.RuneCountInString(str), true
+	}
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len(), true
+	}
+	return 0, false
+}
+
+// keywordOption add the rule which is the keyword of JSON Schema, the keyword is used by JSONSchema
+func keywordOption(keyword string, value interface{}, rule windowRule) FieldOption {
+	return func(field *schemaField) {
+		if field.keywords == nil {
+			field.keywords = make(map[string]interface{})
+		}
+		field.keywords[keyword] = value
+		field.rules = append(field.rules, rule)
+	}
+}
@@ -0,0 +1,200 @@
+package validator
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaValidate(t *testing.T) {
+	type testCase struct {
+		dataReq *message
+		errMsgs []string
+		absence []string
+	}
+	schema := NewSchema().
+		Field("age", Int(), Min(0), Max(150)).
+		Field("name", String()).
+		Field("start", Time(), Optional()).
+		Field("score", Float64(), Optional(), Min(0))
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{"age": 18, "name": "ken", "start": time.Now()}},
+			errMsgs: []string{},
+			absence: []string{"score"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{"age": -1, "name": 3, "score": "60"}},
+			errMsgs: []string{"field age is less than 0", "field name type is not string", "field score type is not float64"},
+			absence: []string{"start"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{"age": 200}},
+			errMsgs: []string{"field age is greater than 150", "field name doesn't exist"},
+			absence: []string{"name", "start", "score"},
+		},
+	}
+	for _, c := range cases {
+		errs, absence := schema.Validate(c.dataReq)
+		errMsgs := []string{}
+		for _, err := range errs {
+			errMsgs = append(errMsgs, err.Error())
+		}
+		assert.Equal(t, c.errMsgs, errMsgs)
+		assert.Equal(t, c.absence, absence)
+	}
+}
+
+func TestSchemaSanitize(t *testing.T) {
+	type testCase struct {
+		dataReq *message
+		out     map[string]interface{}
+		errMsgs []string
+	}
+	schema := NewSchema().
+		Field("age", Int(), Options("trimspace"), Min(0)).
+		Field("name", String(), Options("trimspace", "title")).
+		Field("start", Time(), Options("tz=Asia/Taipei"), Optional())
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{"age": " 18 ", "name": " ken ", "start": "2020-11-06T08:00:00+08:00"}},
+			out: map[string]interface{}{
+				"age":   18,
+				"name":  "Ken",
+				"start": time.Date(2020, 11, 6, 8, 0, 0, 0, time.FixedZone("", 8*60*60)),
+			},
+			errMsgs: []string{},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{"age": "-1", "name": "ken"}},
+			out:     map[string]interface{}{"age": -1, "name": "Ken"},
+			errMsgs: []string{"field age is less than 0"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{"age": "a"}},
+			out:     map[string]interface{}{},
+			errMsgs: []string{"field age type is not int", "field name doesn't exist"},
+		},
+	}
+	for _, c := range cases {
+		out := map[string]interface{}{}
+		errs, _ := schema.Sanitize(c.dataReq, out)
+		errMsgs := []string{}
+		for _, err := range errs {
+			errMsgs = append(errMsgs, err.Error())
+		}
+		assert.Equal(t, c.errMsgs, errMsgs)
+		assert.Equal(t, len(c.out), len(out))
+		for key, val := range c.out {
+			if want, ok := val.(time.Time); ok {
+				assert.True(t, want.Equal(out[key].(time.Time)))
+				continue
+			}
+			assert.Equal(t, val, out[key])
+		}
+	}
+
+	var f foot
+	errs, _ := NewSchema().Field("size", Int(), Max(40)).Sanitize(&message{msg: map[string]interface{}{"size": "42"}}, &f)
+	assert.Equal(t, 42, f.Size)
+	assert.EqualError(t, errs[0], "field size is greater than 40")
+}
+
+func TestSchemaSanitizeNested(t *testing.T) {
+	schema := NewSchema().
+		Field("leg", Properties(NewSchema().Field("number", Int(), Max(4)))).
+		Field("parent", Items(String(), MaxLength(5)), Optional())
+	payload := &message{msg: map[string]interface{}{"leg": `{"number": 2}`, "parent": `["Mary", "Peter"]`}}
+	s := testStruct{}
+	errs, _ := schema.Sanitize(payload, &s)
+	assert.Equal(t, []error{}, errs)
+	assert.Equal(t, leg{Number: 2}, s.Leg)
+	assert.Equal(t, []string{"Mary", "Peter"}, s.Parent)
+
+	payload = &message{msg: map[string]interface{}{"leg": `{"number": 2}`, "parent": "null"}}
+	out := map[string]interface{}{}
+	errs, _ = schema.Sanitize(payload, out)
+	assert.Equal(t, []error{}, errs)
+	assert.Equal(t, map[string]interface{}{"number": float64(2)}, out["leg"])
+	assert.Nil(t, out["parent"])
+
+	payload = &message{msg: map[string]interface{}{"leg": `{"number": 5}`, "parent": `["Mary", "Elizabeth"]`}}
+	out = map[string]interface{}{}
+	errs, _ = schema.Sanitize(payload, out)
+	errMsgs := []string{}
+	for _, err := range errs {
+		errMsgs = append(errMsgs, err.Error())
+	}
+	assert.Equal(t, []string{"field leg.number is greater than 4", "field parent.1 is longer than 5"}, errMsgs)
+}
+
+func TestSchemaSanitizeUnsanitizableType(t *testing.T) {
+	schema := NewSchema().
+		Field("id", Int64()).
+		Field("count", Uint64()).
+		Field("data", Bytes(), Optional())
+	payload := &message{msg: map[string]interface{}{"id": "1", "count": "2", "data": "ok"}}
+	errs, _ := schema.Sanitize(payload, map[string]interface{}{})
+	errMsgs := []string{}
+	for _, err := range errs {
+		assert.IsType(t, ConfigError{}, err)
+		errMsgs = append(errMsgs, err.Error())
+	}
+	assert.Equal(t, []string{
+		"type of id can be checked but not sanitized",
+		"type of count can be checked but not sanitized",
+		"type of data can be checked but not sanitized",
+	}, errMsgs)
+
+	var f foot
+	errs, _ = NewSchema().Field("size", Int32()).Sanitize(&message{msg: map[string]interface{}{"size": "42"}}, &f)
+	assert.Equal(t, 1, len(errs))
+	assert.EqualError(t, errs[0], "type of size can be checked but not sanitized")
+}
+
+func TestSchemaInvalidOptions(t *testing.T) {
+	schema := NewSchema().Field("name", String(), Options("trimspace", "lowr"))
+	errs, _ := schema.Sanitize(&message{msg: map[string]interface{}{"name": " Ken "}}, map[string]interface{}{})
+	if assert.Equal(t, 1, len(errs)) {
+		assert.IsType(t, ConfigError{}, errs[0])
+		assert.EqualError(t, errs[0], "option lowr of name is invalid")
+	}
+}
+
+func TestSchemaEmpty(t *testing.T) {
+	errs, absence := NewSchema().Validate(&message{msg: map[string]interface{}{"age": 18}})
+	assert.Equal(t, []error{}, errs)
+	assert.Equal(t, []string{}, absence)
+	errs, absence = NewSchema().Sanitize(&message{msg: map[string]interface{}{"age": "18"}}, map[string]interface{}{})
+	assert.Equal(t, []error{}, errs)
+	assert.Equal(t, []string{}, absence)
+}
+
+func TestSchemaImmutable(t *testing.T) {
+	base := NewSchema().Field("age", Int())
+	withName := base.Field("name", String())
+	withScore := base.Field("score", Float64())
+	assert.Equal(t, []string{"age"}, base.Params())
+	assert.Equal(t, []string{"age", "name"}, withName.Params())
+	assert.Equal(t, []string{"age", "score"}, withScore.Params())
+}
+
+func TestSchemaConcurrent(t *testing.T) {
+	schema := NewSchema().Field("age", Int(), Min(0)).Field("name", String(), Options("upper"))
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			out := map[string]interface{}{}
+			errs, _ := schema.Sanitize(&message{msg: map[string]interface{}{"age": fmt.Sprint(i - 10), "name": "ken"}}, out)
+			assert.Equal(t, i-10, out["age"])
+			assert.Equal(t, "KEN", out["name"])
+			assert.Equal(t, i < 10, len(errs) == 1)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"fmt"
+	"html"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	tagPattern     = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)\b[^>]*>`)
+	commentPattern = regexp.MustCompile(`(?s)<!--.*?-->`)
+	sqlMeta        = []string{"'", `"`, ";", "--", "/*", "*/", `\`, "\x00"}
+	shellMeta      = []string{";", "|", "&", "$", "`", ">", "<", "(", ")", "{", "}", "*", "?", "!", "~", `\`, "'", `"`, "\n", "\r", "\x00"}
+)
+
+// EscapeHTML escape special characters of html like "<" to "&lt;" before assign
+func (v *SanitizeType) EscapeHTML() *SanitizeType {
+	return v.transform(html.EscapeString)
+}
+
+// StripTags remove html tags and comments before assign, the allowed tags are kept
+// without their attributes
+func (v *SanitizeType) StripTags(allowed ...string) *SanitizeType {
+	return v.transform(stripTags(allowed))
+}
+
+// StripNullBytes remove null bytes before assign
+func (v *SanitizeType) StripNullBytes() *SanitizeType {
+	return v.transform(stripNullBytes)
+}
+
+// SafeFilename keep the base name of path before assign, so the message can't traverse
+// to other directories
+func (v *SanitizeType) SafeFilename() *SanitizeType {
+	return v.transform(safeFilename)
+}
+
+// NoSQLMeta check param has no meta characters of sql, like quote and comment
+func (v *CheckType) NoSQLMeta() *CheckType {
+	return v.noMeta("sql", sqlMeta)
+}
+
+// NoShellMeta check param has no meta characters of shell, like pipe and redirection
+func (v *CheckType) NoShellMeta() *CheckType {
+	return v.noMeta("shell", shellMeta)
+}
+
+func (v *CheckType) noMeta(kind string, metas []string) *CheckType {
+	val, exist := v.handleAbsence()
+	if exist {
+		var err error
+		var str string
+		switch val := val.(type) {
+		case string:
+			str = val
+		case []byte:
+			str = string(val)
+		default:
+			err = newTypeError(v.getParam(), "string")
+		}
+		for _, meta := range metas {
+			if err == nil && strings.Contains(str, meta) {
+				err = newUnsafeContentError(fmt.Sprintf("field %s contains %s meta character %q", v.getParam(), kind, meta), "unsafe", "param", v.getParam(), "kind", kind, "meta", meta)
+			}
+		}
+		v.handleErrors(err)
+	}
+	return v
+}
+
+func stripTags(allowed []string) transform {
+	allowedMap := make(map[string]bool)
+	for _, tag := range allowed {
+		allowedMap[strings.ToLower(tag)] = true
+	}
+	strip := func(val string) string {
+		val = commentPattern.ReplaceAllString(val, "")
+		return tagPattern.ReplaceAllStringFunc(val, func(tag string) string {
+			match := tagPattern.FindStringSubmatch(tag)
+			name := strings.ToLower(match[2])
+			if !allowedMap[name] {
+				return ""
+			}
+			return "<" + match[1] + name + ">"
+		})
+	}
+	// strip until the message stops changing, so the nested tags like <<b>script> can't
+	// make a tag after the inner one is stripped
+	return func(val string) string {
+		for {
+			stripped := strip(val)
+			if stripped == val {
+				return val
+			}
+			val = stripped
+		}
+	}
+}
+
+func stripNullBytes(val string) string {
+	return strings.ReplaceAll(val, "\x00", "")
+}
+
+func safeFilename(val string) string {
+	val = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, val)
+	val = path.Base(strings.ReplaceAll(val, `\`, "/"))
+	if val == "." || val == ".." || val == "/" {
+		return ""
+	}
+	return val
+}
@@ -0,0 +1,154 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeStripTags(t *testing.T) {
+	type testCase struct {
+		dataReq *message
+		allowed []string
+		want    testStruct
+	}
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"desc": `<p>hello <b onclick="alert(1)">ken</b><!-- note --></p><script>`,
+			}},
+			want: testStruct{Description: "hello ken"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"desc": `<p>hello <B onclick="alert(1)">ken</B></p>`,
+			}},
+			allowed: []string{"b"},
+			want:    testStruct{Description: "hello <b>ken</b>"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"desc": `<<b>script>alert(1)<</b>/script>`,
+			}},
+			want: testStruct{Description: "alert(1)"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"desc": `<scr<!-- x -->ipt>alert(1)</script>`,
+			}},
+			want: testStruct{Description: "alert(1)"},
+		},
+	}
+	for _, tc := range cases {
+		actual := testStruct{}
+		Sanitize(tc.dataReq).Params("desc").StripTags(tc.allowed...).ToString(&actual)
+		assert.Equal(t, tc.want, actual)
+	}
+}
+
+func TestSanitizeStripNullBytes(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{}}
+	payload.msg["age"] = "1\x008"
+	expect := testStruct{Age: 18}
+	actual := testStruct{}
+	Sanitize(payload).Params("age").StripNullBytes().ToInt(&actual)
+	assert.Equal(t, expect, actual)
+}
+
+func TestEscapeHTML(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{}}
+	v := Sanitize(payload).EscapeHTML()
+	assert.Equal(t, "&lt;script&gt;alert(&#39;x&#39;)&lt;/script&gt;", v.applyTransforms("<script>alert('x')</script>", nil))
+	v = Sanitize(payload)
+	assert.Equal(t, "a &amp; b", v.applyTransforms("a & b", []string{"escapehtml"}))
+	assert.Equal(t, "<i>x</i>", v.applyTransforms(`<i class="a">x</i><u>`, []string{"striptags=i|b"}))
+}
+
+func TestSafeFilename(t *testing.T) {
+	type testCase struct {
+		data string
+		want string
+	}
+	cases := []testCase{
+		{data: "report.pdf", want: "report.pdf"},
+		{data: "../../etc/passwd", want: "passwd"},
+		{data: `..\..\windows\win.ini`, want: "win.ini"},
+		{data: "..", want: ""},
+		{data: "/", want: ""},
+		{data: "a\x00b\n.txt", want: "ab.txt"},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, safeFilename(tc.data))
+	}
+}
+
+func TestCheckNoSQLMeta(t *testing.T) {
+	type testCase struct {
+		dataReq         *message
+		wantFormatError int
+		wantUnsafe      bool
+	}
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"name": "ken",
+			}},
+			wantFormatError: 0,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"name": "ken' OR 1=1 --",
+			}},
+			wantFormatError: 1,
+			wantUnsafe:      true,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"name": 18,
+			}},
+			wantFormatError: 1,
+			wantUnsafe:      false,
+		},
+	}
+	for _, tc := range cases {
+		Check(tc.dataReq).Params("name").NoSQLMeta()
+		formatErrs, _ := ValidateResult(tc.dataReq)
+		assert.Equal(t, tc.wantFormatError, len(formatErrs))
+		if len(formatErrs) > 0 {
+			_, ok := formatErrs[0].(UnsafeContentError)
+			assert.Equal(t, tc.wantUnsafe, ok)
+		}
+	}
+}
+
+func TestCheckNoShellMeta(t *testing.T) {
+	type testCase struct {
+		dataReq         *message
+		wantFormatError int
+	}
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"host": "example.com",
+			}},
+			wantFormatError: 0,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"host": "example.com; rm -rf /",
+			}},
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"host": []byte("$(reboot)"),
+			}},
+			wantFormatError: 1,
+		},
+	}
+	for _, tc := range cases {
+		Check(tc.dataReq).Params("host").NoShellMeta()
+		formatErrs, _ := ValidateResult(tc.dataReq)
+		assert.Equal(t, tc.wantFormatError, len(formatErrs))
+	}
+}
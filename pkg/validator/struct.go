@@ -0,0 +1,183 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	timeReflectType     = reflect.TypeOf(time.Time{})
+	durationReflectType = reflect.TypeOf(time.Duration(0))
+	ipReflectType       = reflect.TypeOf(net.IP{})
+	locationReflectType = reflect.TypeOf(&time.Location{})
+)
+
+// objectPayload is the payload of nested json object, its params are the keys of object
+type objectPayload struct {
+	prefix string
+	values map[string]json.RawMessage
+	cache  map[string]interface{}
+}
+
+func (p *objectPayload) GetCache() map[string]interface{} {
+	if p.cache == nil {
+		p.cache = make(map[string]interface{})
+	}
+	return p.cache
+}
+
+func (p *objectPayload) SetCache(input map[string]interface{}) {
+	p.cache = input
+}
+
+// GetParam get value of key, json string is unquoted and other values are kept as json,
+// the null value is treated as absence
+func (p *objectPayload) GetParam(field string) (val interface{}, exist bool) {
+	raw, ok := p.values[strings.TrimPrefix(field, p.prefix)]
+	if !ok || string(raw) == "null" {
+		return nil, false
+	}
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return str, true
+	}
+	return string(raw), true
+}
+
+// isString report the value of key is json string
+func (p *objectPayload) isString(field string) bool {
+	var str string
+	return json.Unmarshal(p.values[strings.TrimPrefix(field, p.prefix)], &str) == nil
+}
+
+// dataTypeOf get the data type to sanitize the field of type
+func dataTypeOf(fieldType reflect.Type) int {
+	if fieldType.Kind() == reflect.Ptr && fieldType != locationReflectType {
+		fieldType = fieldType.Elem()
+	}
+	switch fieldType {
+	case timeReflectType:
+		return timeType
+	case durationReflectType:
+		return durationType
+	case ipReflectType:
+		return ipType
+	case locationReflectType:
+		return locationType
+	}
+	switch fieldType.Kind() {
+	case reflect.Int:
+		return intType
+	case reflect.Uint32:
+		return uint32Type
+	case reflect.Float64:
+		return float64Type
+	case reflect.Bool:
+		return boolType
+	case reflect.String:
+		return stringType
+	}
+	return objectType
+}
+
+// isTaggedStruct report type is struct, or pointer to struct, with vld tags
+func isTaggedStruct(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() != reflect.Struct || fieldType == timeReflectType {
+		return false
+	}
+	return len(structInfoOf(fieldType).tags) > 0
+}
+
+// sanitizeNested sanitize json object into struct. The fields without vld tags are decoded by json
+// like ToObject, and then the tagged fields are sanitized by their tags like top-level params,
+// the errors and absence of nested params are merged into the session with the path of param,
+// like "leg.number". The field is optional when its tag has optional option, like
+// `vld:"number,optional"`, and the time layouts and transforms of chain are used by nested fields
+func (v *SanitizeType) sanitizeNested(val string, field reflect.Value) error {
+	values := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(val), &values); err != nil {
+		return newTypeError(v.getParam(), "object", "value", val)
+	}
+	if field.Kind() == reflect.Ptr {
+		field.Set(reflect.New(field.Type().Elem()))
+		field = field.Elem()
+	}
+	info := structInfoOf(field.Type())
+	untagged := map[string]json.RawMessage{}
+	unknown := []string{}
+	for key, raw := range values {
+		if _, ok := info.byParam[key]; ok {
+			continue
+		}
+		if !isJSONField(field.Type(), key) {
+			unknown = append(unknown, key)
+			continue
+		}
+		untagged[key] = raw
+	}
+	if err := v.decodeUntagged(untagged, field); err != nil {
+		return err
+	}
+	nested := &objectPayload{prefix: v.getParam() + ".", values: values}
+	sanitizer := Sanitize(nested)
+	sanitizer.prefix = nested.prefix
+	sanitizer.jsonOptions = v.jsonOptions
+	sanitizer.timeFormats = v.timeFormats
+	sanitizer.unixUnit = v.unixUnit
+	sanitizer.transforms = v.transforms
+	v.inheritSession(nested)
+	out := field.Addr().Interface()
+	for _, param := range info.tags {
+		fieldInfo := info.byParam[param]
+		_, sanitizer.optional = tagOption(fieldInfo.options, "optional")
+		sanitizer.Params(fieldInfo.param).toValue(out, fieldInfo.dataType)
+	}
+	if v.jsonOptions.disallowUnknownFields {
+		sort.Strings(unknown)
+		for _, key := range unknown {
+			sanitizer.handleErrors(newUnknownParamError(fmt.Sprintf("field %s%s is unknown", nested.prefix, key), "unknown", "param", nested.prefix+key))
+		}
+	}
+	v.mergeResult(ValidateResult(nested))
+	return nil
+}
+
+// decodeUntagged decode the keys not tagged by vld into struct by json, like json.Unmarshal
+func (v *SanitizeType) decodeUntagged(values map[string]json.RawMessage, field reflect.Value) error {
+	if len(values) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return newTypeError(v.getParam(), "json", "value", string(data))
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if v.jsonOptions.useNumber {
+		decoder.UseNumber()
+	}
+	if err := decoder.Decode(field.Addr().Interface()); err != nil {
+		return newTypeError(v.getParam(), "json", "value", string(data))
+	}
+	return nil
+}
+
+// isJSONField report key matches a field of struct type by json, like the json tag or the
+// field name, in the same way as json.Unmarshal
+func isJSONField(structType reflect.Type, key string) bool {
+	data, err := json.Marshal(map[string]interface{}{key: nil})
+	if err != nil {
+		return false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode(reflect.New(structType).Interface()) == nil
+}
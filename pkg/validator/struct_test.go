@@ -0,0 +1,152 @@
+package validator
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type toe struct {
+	Length float64 `vld:"length"`
+}
+
+type foot struct {
+	Size    int       `vld:"size"`
+	Color   string    `vld:"color,optional,trimspace,lower"`
+	Bought  time.Time `vld:"bought,tz=Asia/Taipei"`
+	Shop    net.IP    `vld:"shop,optional"`
+	BigToe  *toe      `vld:"bigToe,optional"`
+	Comment string
+	Brand   string `json:"brand_name"`
+}
+
+type body struct {
+	Foot foot `vld:"foot"`
+}
+
+func TestSanitizeNested(t *testing.T) {
+	taipei, _ := time.LoadLocation("Asia/Taipei")
+	type testCase struct {
+		dataReq         *message
+		options         func(v *SanitizeType) *SanitizeType
+		want            body
+		wantAbsence     []string
+		wantFormatError int
+	}
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"foot": `{"size": 42, "color": " RED ", "bought": "2020-11-06T16:19:23", "bigToe": {"length": 1.5}}`,
+			}},
+			options: func(v *SanitizeType) *SanitizeType { return v.DisallowUnknownFields() },
+			want: body{Foot: foot{
+				Size:   42,
+				Color:  "red",
+				Bought: time.Date(2020, 11, 6, 16, 19, 23, 0, taipei),
+				BigToe: &toe{Length: 1.5},
+			}},
+			wantAbsence: []string{"foot.shop"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"foot": `{"size": "42A", "bought": null, "bigToe": {"length": "long"}}`,
+			}},
+			options:         func(v *SanitizeType) *SanitizeType { return v },
+			want:            body{Foot: foot{BigToe: &toe{}}},
+			wantAbsence:     []string{"foot.color", "foot.bought", "foot.shop"},
+			wantFormatError: 3,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"foot": `{"size": 42, "bought": "2020-11-06T16:19:23", "comment": "nice", "brand_name": "kk", "Brand": "x"}`,
+			}},
+			options: func(v *SanitizeType) *SanitizeType { return v.DisallowUnknownFields() },
+			want: body{Foot: foot{
+				Size:    42,
+				Bought:  time.Date(2020, 11, 6, 16, 19, 23, 0, taipei),
+				Comment: "nice",
+				Brand:   "kk",
+			}},
+			wantAbsence:     []string{"foot.color", "foot.shop", "foot.bigToe"},
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"foot": `{"size": 42, "color": 5, "bought": "2020-11-06T16:19:23"}`,
+			}},
+			options:         func(v *SanitizeType) *SanitizeType { return v },
+			want:            body{Foot: foot{Size: 42, Bought: time.Date(2020, 11, 6, 16, 19, 23, 0, taipei)}},
+			wantAbsence:     []string{"foot.shop", "foot.bigToe"},
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"foot": `{"size": 42, "color": "B\u0000LUE", "bought": "2020-11-06T16:19:23", "comment": 5}`,
+			}},
+			options:         func(v *SanitizeType) *SanitizeType { return v.StripNullBytes() },
+			want:            body{},
+			wantAbsence:     []string{},
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"foot": `{"size": 42, "color": "B\u0000LUE", "bought": "2020-11-06T16:19:23"}`,
+			}},
+			options:     func(v *SanitizeType) *SanitizeType { return v.StripNullBytes() },
+			want:        body{Foot: foot{Size: 42, Color: "blue", Bought: time.Date(2020, 11, 6, 16, 19, 23, 0, taipei)}},
+			wantAbsence: []string{"foot.shop", "foot.bigToe"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"foot": `{"size": 42, "color": "<b>red</b>", "bought": "2020-11-06T16:19:23"}`,
+			}},
+			options:     func(v *SanitizeType) *SanitizeType { return v.EscapeHTML() },
+			want:        body{Foot: foot{Size: 42, Color: "&lt;b&gt;red&lt;/b&gt;", Bought: time.Date(2020, 11, 6, 16, 19, 23, 0, taipei)}},
+			wantAbsence: []string{"foot.shop", "foot.bigToe"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"foot": `{"size": 42, "color": "red", "bought": "2020-11-06t16:19:23"}`,
+			}},
+			options:     func(v *SanitizeType) *SanitizeType { return v.Upper() },
+			want:        body{Foot: foot{Size: 42, Color: "red", Bought: time.Date(2020, 11, 6, 16, 19, 23, 0, taipei)}},
+			wantAbsence: []string{"foot.shop", "foot.bigToe"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"foot": `[42]`,
+			}},
+			options:         func(v *SanitizeType) *SanitizeType { return v },
+			want:            body{},
+			wantAbsence:     []string{},
+			wantFormatError: 1,
+		},
+	}
+	for _, tc := range cases {
+		actual := body{}
+		tc.options(Sanitize(tc.dataReq).Params("foot")).TimeFormat("2006-01-02T15:04:05").ToStruct(&actual)
+		errs, absence := ValidateResult(tc.dataReq)
+		assert.Equal(t, tc.wantFormatError, len(errs))
+		assert.Equal(t, tc.wantAbsence, absence)
+		assert.Equal(t, tc.want.Foot.Size, actual.Foot.Size)
+		assert.Equal(t, tc.want.Foot.Color, actual.Foot.Color)
+		assert.True(t, tc.want.Foot.Bought.Equal(actual.Foot.Bought))
+		assert.Equal(t, tc.want.Foot.BigToe, actual.Foot.BigToe)
+		assert.Equal(t, tc.want.Foot.Comment, actual.Foot.Comment)
+		assert.Equal(t, tc.want.Foot.Brand, actual.Foot.Brand)
+	}
+}
+
+func TestSanitizeNestedAbsence(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"foot": `{}`,
+	}}
+	actual := body{}
+	Sanitize(payload).Params("foot").ToStruct(&actual)
+	errs, _ := ValidateResult(payload)
+	assert.Equal(t, 2, len(errs))
+	assert.Equal(t, "field foot.size doesn't exist", errs[0].Error())
+	assert.Equal(t, "field foot.bought doesn't exist", errs[1].Error())
+}
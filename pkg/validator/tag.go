@@ -0,0 +1,60 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+)
+
+const tagName = "vld"
+
+// parseTag split vld tag into param name and options, like `vld:"name,trimspace,lower"`
+func parseTag(tag string) (string, []string) {
+	parts := strings.Split(tag, ",")
+	return parts[0], parts[1:]
+}
+
+// tagOption get value of option like `tz=Asia/Taipei`, an option without value returns empty string
+func tagOption(options []string, key string) (string, bool) {
+	for _, option := range options {
+		if name, value := splitOption(option); name == key {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+func splitOption(option string) (string, string) {
+	if i := strings.Index(option, "="); i >= 0 {
+		return option[:i], option[i+1:]
+	}
+	return option, ""
+}
+
+// IsTagOption report option is a known and well-formed option of vld tag, the option can have
+// value like "tz=Asia/Taipei" and "replace=old:new". It is used by vldgen to find typos of tag
+func IsTagOption(option string) bool {
+	name, value := splitOption(option)
+	hasValue := strings.Contains(option, "=")
+	switch name {
+	case "optional", "utc":
+		return !hasValue
+	case "tz", "trim":
+		return value != ""
+	case "replace":
+		return strings.Contains(value, ":")
+	case "striptags":
+		return true
+	}
+	_, ok := tagTransforms[name]
+	return ok && !hasValue
+}
+
+// checkOptions report the unknown or malformed option of param as ConfigError
+func checkOptions(param string, options []string) error {
+	for _, option := range options {
+		if !IsTagOption(option) {
+			return newConfigError(fmt.Sprintf("option %s of %s is invalid", option, param), "param", param)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// RFCTimeFormats are the time layouts of RFC
+var RFCTimeFormats = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.RFC822Z,
+	time.RFC822,
+}
+
+// DateTimeFormats are the common time layouts of date and time without time zone
+var DateTimeFormats = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006/01/02 15:04:05",
+	"2006-01-02",
+	"2006/01/02",
+}
+
+var isoDurationPattern = regexp.MustCompile(`^(-)?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// defaultTimeFormats is used when no time layout is provided
+var defaultTimeFormats = []string{time.RFC3339}
+
+// TimeFormat provide time format info to sanitize
+func (v *SanitizeType) TimeFormat(str string) *SanitizeType {
+	return v.TimeFormats(str)
+}
+
+// TimeFormats provide time layouts to sanitize, they are tried in order until one of them
+// parses the message, like TimeFormats(time.RFC3339, "2006-01-02")
+func (v *SanitizeType) TimeFormats(layouts ...string) *SanitizeType {
+	v.timeFormats = nil
+	for _, layout := range layouts {
+		if layout != "" {
+			v.timeFormats = append(v.timeFormats, layout)
+		}
+	}
+	v.unixUnit = 0
+	return v
+}
+
+// UnixSeconds sanitize message as unix time in seconds
+func (v *SanitizeType) UnixSeconds() *SanitizeType {
+	v.unixUnit = time.Second
+	return v
+}
+
+// UnixMillis sanitize message as unix time in milliseconds
+func (v *SanitizeType) UnixMillis() *SanitizeType {
+	v.unixUnit = time.Millisecond
+	return v
+}
+
+// UnixNanos sanitize message as unix time in nanoseconds
+func (v *SanitizeType) UnixNanos() *SanitizeType {
+	v.unixUnit = time.Nanosecond
+	return v
+}
+
+// InLocation parse time in the location, the message without time zone is treated as the time
+// of location. It overrides the tz option of vld tag, like `vld:"start,tz=Asia/Taipei"`
+func (v *SanitizeType) InLocation(loc *time.Location) *SanitizeType {
+	v.location, v.unknownZone = loc, ""
+	return v
+}
+
+// InZone parse time in the location of IANA time zone name, like "Asia/Taipei"
+func (v *SanitizeType) InZone(name string) *SanitizeType {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		v.location, v.unknownZone = nil, name
+		return v
+	}
+	return v.InLocation(loc)
+}
+
+// UTC normalize all parsed times of the session to UTC, a single field can be normalized
+// by the utc option of vld tag, like `vld:"start,utc"`
+func (v *SanitizeType) UTC() *SanitizeType {
+	cache := v.content.GetCache()
+	cache[contextKey].(map[string]interface{})[utcKey] = true
+	return v
+}
+
+func (v *SanitizeType) toTime(val string, local bool, options []string) (time.Time, error) {
+	loc, err := v.timeLocation(local, options)
+	if err != nil {
+		return time.Time{}, err
+	}
+	timeVal, err := v.parseTime(val, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	cache := v.content.GetCache()
+	_, utcOption := tagOption(options, "utc")
+	if utc, _ := cache[contextKey].(map[string]interface{})[utcKey].(bool); utc || utcOption {
+		timeVal = timeVal.UTC()
+	}
+	return timeVal, nil
+}
+
+// timeLocation get the location of message without time zone, the location of chain and the tz
+// option go first, and ToLocalTime falls back to time.Local when neither is set
+func (v *SanitizeType) timeLocation(local bool, options []string) (*time.Location, error) {
+	if v.unknownZone != "" {
+		return nil, newConfigError(fmt.Sprintf("time zone %v of %s is unknown", v.unknownZone, v.getParam()), "param", v.getParam())
+	}
+	if v.location != nil {
+		return v.location, nil
+	}
+	if zone, ok := tagOption(options, "tz"); ok {
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			return nil, newConfigError(fmt.Sprintf("time zone %v of %s is unknown", zone, v.getParam()), "param", v.getParam())
+		}
+		return loc, nil
+	}
+	if local {
+		return time.Local, nil
+	}
+	return time.UTC, nil
+}
+
+func (v *SanitizeType) parseTime(val string, loc *time.Location) (time.Time, error) {
+	if v.unixUnit != 0 {
+		unix, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return time.Time{}, newTypeError(v.getParam(), "unix time", "value", val)
+		}
+		// split into seconds and the rest, multiplying by unit overflows nanoseconds of int64
+		perSecond := int64(time.Second / v.unixUnit)
+		return time.Unix(unix/perSecond, unix%perSecond*int64(v.unixUnit)).In(loc), nil
+	}
+	layouts := v.timeFormats
+	if len(layouts) == 0 {
+		layouts = defaultTimeFormats
+	}
+	var parseErr error
+	for _, layout := range layouts {
+		timeVal, err := time.ParseInLocation(layout, val, loc)
+		if err == nil {
+			return timeVal, nil
+		}
+		parseErr = err
+	}
+	err := newTypeError(v.getParam(), "time", "value", val, "layouts", layouts)
+	err.message = fmt.Sprintf("%s in layouts %q. parse error: %v", err.message, layouts, parseErr.Error())
+	err.Layouts = layouts
+	return time.Time{}, err
+}
+
+// parseDuration parse duration of go like "1h30m", seconds like "90", or ISO 8601 duration
+// like "PT1H30M". Years and months of ISO 8601 are not supported, because their length vary
+func parseDuration(val string) (time.Duration, error) {
+	if seconds, err := strconv.ParseFloat(val, 64); err == nil && !math.IsNaN(seconds) && !math.IsInf(seconds, 0) {
+		return toDuration(seconds * float64(time.Second))
+	}
+	if duration, err := time.ParseDuration(val); err == nil {
+		return duration, nil
+	}
+	match := isoDurationPattern.FindStringSubmatch(val)
+	if match == nil || val == "P" || val == "-P" || val[len(val)-1] == 'T' {
+		return 0, errors.New("invalid duration")
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var nanos float64
+	for i, unit := range units {
+		if match[i+2] == "" {
+			continue
+		}
+		number, err := strconv.ParseFloat(match[i+2], 64)
+		if err != nil {
+			return 0, err
+		}
+		nanos += number * float64(unit)
+	}
+	if match[1] == "-" {
+		nanos = -nanos
+	}
+	return toDuration(nanos)
+}
+
+// toDuration convert nanoseconds to duration, the nanoseconds out of int64 are reported as error
+// instead of overflowing
+func toDuration(nanos float64) (time.Duration, error) {
+	if nanos >= math.MaxInt64 || nanos < math.MinInt64 {
+		return 0, errors.New("duration out of range")
+	}
+	return time.Duration(nanos), nil
+}
@@ -0,0 +1,327 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeTimeFormats(t *testing.T) {
+	type testCase struct {
+		dataReq         *message
+		layouts         []string
+		want            testStruct
+		wantFormatError int
+	}
+	dateVal, _ := time.Parse("2006-01-02", "2020-11-06")
+	rfcVal, _ := time.Parse(time.RFC3339, "2020-11-06T16:19:23+08:00")
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"startTime": "2020-11-06",
+			}},
+			layouts: []string{time.RFC3339, "2006-01-02"},
+			want:    testStruct{StartTime: dateVal},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"startTime": "2020-11-06T16:19:23+08:00",
+			}},
+			layouts: RFCTimeFormats,
+			want:    testStruct{StartTime: rfcVal},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"startTime": "2020-11-06T16:19:23+08:00",
+			}},
+			layouts: nil,
+			want:    testStruct{StartTime: rfcVal},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"startTime": "06/11/2020",
+			}},
+			layouts:         DateTimeFormats,
+			want:            testStruct{},
+			wantFormatError: 1,
+		},
+	}
+	for _, tc := range cases {
+		actual := testStruct{}
+		Sanitize(tc.dataReq).Params("startTime").TimeFormats(tc.layouts...).ToTime(&actual)
+		errs, _ := ValidateResult(tc.dataReq)
+		assert.Equal(t, tc.wantFormatError, len(errs))
+		assert.True(t, tc.want.StartTime.Equal(actual.StartTime))
+		if len(errs) > 0 {
+			assert.Equal(t, tc.layouts, errs[0].(WrongTypeError).Layouts)
+		}
+	}
+}
+
+func TestSanitizeUnixTime(t *testing.T) {
+	type testCase struct {
+		dataReq         *message
+		unit            func(v *SanitizeType) *SanitizeType
+		want            time.Time
+		wantFormatError int
+	}
+	want := time.Unix(1604650763, 0)
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"startTime": "1604650763",
+			}},
+			unit: (*SanitizeType).UnixSeconds,
+			want: want,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"startTime": "1604650763000",
+			}},
+			unit: (*SanitizeType).UnixMillis,
+			want: want,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"startTime": "1604650763000000000",
+			}},
+			unit: (*SanitizeType).UnixNanos,
+			want: want,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"startTime": "99999999999999",
+			}},
+			unit: (*SanitizeType).UnixSeconds,
+			want: time.Unix(99999999999999, 0),
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"startTime": "-1604650763500",
+			}},
+			unit: (*SanitizeType).UnixMillis,
+			want: time.Unix(-1604650764, 500000000),
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"startTime": "2020-11-06",
+			}},
+			unit:            (*SanitizeType).UnixSeconds,
+			want:            time.Time{},
+			wantFormatError: 1,
+		},
+	}
+	for _, tc := range cases {
+		actual := testStruct{}
+		tc.unit(Sanitize(tc.dataReq).Params("startTime")).ToTime(&actual)
+		errs, _ := ValidateResult(tc.dataReq)
+		assert.Equal(t, tc.wantFormatError, len(errs))
+		assert.True(t, tc.want.Equal(actual.StartTime))
+	}
+}
+
+type zoneStruct struct {
+	Start    time.Time      `vld:"start,tz=Asia/Taipei"`
+	End      time.Time      `vld:"end,tz=Asia/Taipei,utc"`
+	Bad      time.Time      `vld:"bad,tz=Mars/Olympus"`
+	Location *time.Location `vld:"zone"`
+}
+
+func TestSanitizeInZone(t *testing.T) {
+	taipei, _ := time.LoadLocation("Asia/Taipei")
+	want := time.Date(2020, 11, 6, 16, 19, 23, 0, taipei)
+	type testCase struct {
+		dataReq         *message
+		zone            func(v *SanitizeType) *SanitizeType
+		want            time.Time
+		wantFormatError int
+	}
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"startTime": "2020-11-06 16:19:23",
+			}},
+			zone: func(v *SanitizeType) *SanitizeType { return v.InLocation(taipei) },
+			want: want,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"startTime": "2020-11-06 16:19:23",
+			}},
+			zone: func(v *SanitizeType) *SanitizeType { return v.InZone("Asia/Taipei") },
+			want: want,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"startTime": "2020-11-06 16:19:23",
+			}},
+			zone:            func(v *SanitizeType) *SanitizeType { return v.InZone("Mars/Olympus") },
+			want:            time.Time{},
+			wantFormatError: 1,
+		},
+	}
+	for _, tc := range cases {
+		actual := testStruct{}
+		tc.zone(Sanitize(tc.dataReq).Params("startTime").TimeFormat("2006-01-02 15:04:05")).ToTime(&actual)
+		errs, _ := ValidateResult(tc.dataReq)
+		assert.Equal(t, tc.wantFormatError, len(errs))
+		assert.True(t, tc.want.Equal(actual.StartTime))
+		assert.Equal(t, tc.want.Location(), actual.StartTime.Location())
+
+		actual = testStruct{}
+		tc.zone(Sanitize(tc.dataReq).Params("startTime").TimeFormat("2006-01-02 15:04:05")).ToLocalTime(&actual)
+		errs, _ = ValidateResult(tc.dataReq)
+		assert.Equal(t, tc.wantFormatError, len(errs))
+		assert.True(t, tc.want.Equal(actual.StartTime))
+		assert.Equal(t, tc.want.Location(), actual.StartTime.Location())
+	}
+}
+
+func TestSanitizeZoneTag(t *testing.T) {
+	taipei, _ := time.LoadLocation("Asia/Taipei")
+	want := time.Date(2020, 11, 6, 16, 19, 23, 0, taipei)
+	payload := &message{msg: map[string]interface{}{
+		"start": "2020-11-06 16:19:23",
+		"end":   "2020-11-06 16:19:23",
+		"bad":   "2020-11-06 16:19:23",
+		"zone":  "Asia/Taipei",
+	}}
+	actual := zoneStruct{}
+	Sanitize(payload).Params("start").TimeFormat("2006-01-02 15:04:05").ToTime(&actual)
+	Sanitize(payload).Params("end").TimeFormat("2006-01-02 15:04:05").ToTime(&actual)
+	Sanitize(payload).Params("zone").ToLocation(&actual)
+	errs, _ := ValidateResult(payload)
+	assert.Equal(t, 0, len(errs))
+	assert.Equal(t, want, actual.Start)
+	assert.Equal(t, want.UTC(), actual.End)
+	assert.Equal(t, taipei, actual.Location)
+
+	Sanitize(payload).Params("bad").TimeFormat("2006-01-02 15:04:05").ToTime(&actual)
+	Sanitize(payload).Params("start").InLocation(time.UTC).TimeFormat("2006-01-02 15:04:05").ToTime(&actual)
+	errs, _ = ValidateResult(payload)
+	assert.Equal(t, 1, len(errs))
+	assert.Equal(t, time.UTC, actual.Start.Location())
+}
+
+func TestSanitizeUTC(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"startTime": "2020-11-06T16:19:23+08:00",
+		"endTime":   "2020-11-06T17:19:23+08:00",
+	}}
+	actual := testStruct{}
+	Sanitize(payload).UTC().Params("startTime").ToTime(&actual)
+	Sanitize(payload).Params("endTime").ToTime(&actual)
+	assert.Equal(t, time.Date(2020, 11, 6, 8, 19, 23, 0, time.UTC), actual.StartTime)
+	assert.Equal(t, time.Date(2020, 11, 6, 9, 19, 23, 0, time.UTC), *actual.EndTime)
+}
+
+func TestSanitizeLocation(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"zone": "Nowhere/City",
+	}}
+	actual := zoneStruct{}
+	Sanitize(payload).Params("zone").ToLocation(&actual)
+	errs, _ := ValidateResult(payload)
+	assert.Equal(t, 1, len(errs))
+	assert.Nil(t, actual.Location)
+}
+
+type durationStruct struct {
+	Timeout    time.Duration  `vld:"timeout"`
+	TimeoutPtr *time.Duration `vld:"timeoutPtr"`
+}
+
+func TestSanitizeDuration(t *testing.T) {
+	type testCase struct {
+		dataReq         *message
+		dataField       string
+		want            durationStruct
+		wantFormatError int
+	}
+	timeout := 90 * time.Minute
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"timeout": "1h30m",
+			}},
+			dataField: "timeout",
+			want:      durationStruct{Timeout: timeout},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"timeout": "5400",
+			}},
+			dataField: "timeout",
+			want:      durationStruct{Timeout: timeout},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"timeoutPtr": "PT1H30M",
+			}},
+			dataField: "timeoutPtr",
+			want:      durationStruct{TimeoutPtr: &timeout},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"timeout": "P1W1DT0.5S",
+			}},
+			dataField: "timeout",
+			want:      durationStruct{Timeout: 8*24*time.Hour + 500*time.Millisecond},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"timeout": "-PT1M",
+			}},
+			dataField: "timeout",
+			want:      durationStruct{Timeout: -time.Minute},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"timeout": "PT",
+			}},
+			dataField:       "timeout",
+			want:            durationStruct{},
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"timeout": "P1Y",
+			}},
+			dataField:       "timeout",
+			want:            durationStruct{},
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"timeout": "NaN",
+			}},
+			dataField:       "timeout",
+			want:            durationStruct{},
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"timeout": "1e12",
+			}},
+			dataField:       "timeout",
+			want:            durationStruct{},
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"timeout": "-P99999999W",
+			}},
+			dataField:       "timeout",
+			want:            durationStruct{},
+			wantFormatError: 1,
+		},
+	}
+	for _, tc := range cases {
+		actual := durationStruct{}
+		Sanitize(tc.dataReq).Params(tc.dataField).ToDuration(&actual)
+		errs, _ := ValidateResult(tc.dataReq)
+		assert.Equal(t, tc.wantFormatError, len(errs))
+		assert.Equal(t, tc.want, actual)
+	}
+}
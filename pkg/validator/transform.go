@@ -0,0 +1,154 @@
+package validator
+
+import (
+	"html"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// transform pre-process the message before it is converted
+type transform func(string) string
+
+// tagTransforms are transforms can be declared by options of vld tag, like `vld:"name,trimspace,title"`
+var tagTransforms = map[string]func(arg string) transform{
+	"trimspace":      func(string) transform { return strings.TrimSpace },
+	"lower":          func(string) transform { return strings.ToLower },
+	"upper":          func(string) transform { return strings.ToUpper },
+	"title":          func(string) transform { return toTitle },
+	"collapsespaces": func(string) transform { return collapseSpaces },
+	"nfc":            func(string) transform { return norm.NFC.String },
+	"nfkc":           func(string) transform { return norm.NFKC.String },
+	"stripcontrol":   func(string) transform { return stripControlChars },
+	"escapehtml":     func(string) transform { return html.EscapeString },
+	"stripnull":      func(string) transform { return stripNullBytes },
+	"safefilename":   func(string) transform { return safeFilename },
+	"striptags": func(arg string) transform {
+		// the allowed tags of striptags is like `striptags=b|i|p`
+		var allowed []string
+		if arg != "" {
+			allowed = strings.Split(arg, "|")
+		}
+		return stripTags(allowed)
+	},
+	"trim": func(cutset string) transform {
+		return func(val string) string { return strings.Trim(val, cutset) }
+	},
+	"replace": func(arg string) transform {
+		// the argument of replace is like `replace=old:new`
+		parts := strings.SplitN(arg, ":", 2)
+		if len(parts) != 2 {
+			return func(val string) string { return val }
+		}
+		return func(val string) string { return strings.ReplaceAll(val, parts[0], parts[1]) }
+	},
+}
+
+// TrimSpace remove the leading and trailing white space before assign
+func (v *SanitizeType) TrimSpace() *SanitizeType {
+	return v.transform(strings.TrimSpace)
+}
+
+// Lower map message to lower case before assign
+func (v *SanitizeType) Lower() *SanitizeType {
+	return v.transform(strings.ToLower)
+}
+
+// Upper map message to upper case before assign
+func (v *SanitizeType) Upper() *SanitizeType {
+	return v.transform(strings.ToUpper)
+}
+
+// Title map message to title case before assign
+func (v *SanitizeType) Title() *SanitizeType {
+	return v.transform(toTitle)
+}
+
+// CollapseSpaces replace every run of white space with a single space before assign
+func (v *SanitizeType) CollapseSpaces() *SanitizeType {
+	return v.transform(collapseSpaces)
+}
+
+// NFC normalize message to unicode normalization form C before assign
+func (v *SanitizeType) NFC() *SanitizeType {
+	return v.transform(norm.NFC.String)
+}
+
+// NFKC normalize message to unicode normalization form KC before assign
+func (v *SanitizeType) NFKC() *SanitizeType {
+	return v.transform(norm.NFKC.String)
+}
+
+// StripControlChars remove control characters except tab and line breaks before assign
+func (v *SanitizeType) StripControlChars() *SanitizeType {
+	return v.transform(stripControlChars)
+}
+
+// Replace all old string of message with new one before assign
+func (v *SanitizeType) Replace(old, new string) *SanitizeType {
+	return v.transform(func(val string) string {
+		return strings.ReplaceAll(val, old, new)
+	})
+}
+
+func (v *SanitizeType) transform(t transform) *SanitizeType {
+	v.transforms = append(v.transforms, t)
+	return v
+}
+
+// applyTransforms run transforms of chain in declared order, and then the transforms of tag options
+func (v *SanitizeType) applyTransforms(val string, options []string) string {
+	for _, t := range v.transforms {
+		val = t(val)
+	}
+	for _, option := range options {
+		name, arg := splitOption(option)
+		if newTransform, ok := tagTransforms[name]; ok {
+			val = newTransform(arg)(val)
+		}
+	}
+	return val
+}
+
+// transformJSON run transforms on json message decoded into field, the message of struct with vld
+// tags is kept as it is, because the transforms of chain run on its nested fields instead
+func (v *SanitizeType) transformJSON(val string, options []string, field reflect.Value) string {
+	if isTaggedStruct(field.Type()) {
+		return val
+	}
+	return v.applyTransforms(val, options)
+}
+
+func toTitle(val string) string {
+	return cases.Title(language.Und).String(val)
+}
+
+func collapseSpaces(val string) string {
+	var builder strings.Builder
+	inSpace := false
+	for _, r := range val {
+		if unicode.IsSpace(r) {
+			if !inSpace {
+				builder.WriteRune(' ')
+			}
+			inSpace = true
+			continue
+		}
+		inSpace = false
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+func stripControlChars(val string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, val)
+}
@@ -0,0 +1,151 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type transformStruct struct {
+	Name    string `vld:"name,collapsespaces,title"`
+	Code    string `vld:"code,nfkc,upper"`
+	Comment string `vld:"comment,replace=-:_"`
+}
+
+type badTransformStruct struct {
+	Name    string `vld:"name,trimspce"`
+	Comment string `vld:"comment,replace=-"`
+}
+
+func TestSanitizeTransform(t *testing.T) {
+	type testCase struct {
+		dataReq   *message
+		transform func(v *SanitizeType) *SanitizeType
+		want      testStruct
+	}
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"age": " 18\t",
+			}},
+			transform: (*SanitizeType).TrimSpace,
+			want:      testStruct{Age: 18},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"alive": "TRUE",
+			}},
+			transform: (*SanitizeType).Lower,
+			want:      testStruct{IsAlive: true},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"name": "Ken",
+			}},
+			transform: (*SanitizeType).Upper,
+			want:      testStruct{Name: "KEN"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"name": "ken chen",
+			}},
+			transform: (*SanitizeType).Title,
+			want:      testStruct{Name: "Ken Chen"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"name": "ken  \t　 chen",
+			}},
+			transform: (*SanitizeType).CollapseSpaces,
+			want:      testStruct{Name: "ken chen"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"name": "e\u0301",
+			}},
+			transform: (*SanitizeType).NFC,
+			want:      testStruct{Name: "\u00e9"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"name": "Ｋｅｎ",
+			}},
+			transform: (*SanitizeType).NFKC,
+			want:      testStruct{Name: "Ken"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"name": "k\x00e\x1bn",
+			}},
+			transform: (*SanitizeType).StripControlChars,
+			want:      testStruct{Name: "ken"},
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"age": "1,800",
+			}},
+			transform: func(v *SanitizeType) *SanitizeType { return v.Replace(",", "") },
+			want:      testStruct{Age: 1800},
+		},
+	}
+	for _, tc := range cases {
+		actual := testStruct{}
+		for param := range tc.dataReq.msg {
+			switch param {
+			case "age":
+				tc.transform(Sanitize(tc.dataReq).Params(param)).ToInt(&actual)
+			case "alive":
+				tc.transform(Sanitize(tc.dataReq).Params(param)).ToBool(&actual)
+			default:
+				tc.transform(Sanitize(tc.dataReq).Params(param)).ToString(&actual)
+			}
+		}
+		errs, _ := ValidateResult(tc.dataReq)
+		assert.Equal(t, 0, len(errs))
+		assert.Equal(t, tc.want, actual)
+	}
+}
+
+func TestSanitizeTransformOrder(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{}}
+	payload.msg["age"] = " !18! "
+	expect := testStruct{Age: 18}
+	actual := testStruct{}
+	Sanitize(payload).Params("age").TrimSpace().Trim("!").ToInt(&actual)
+	assert.Equal(t, expect, actual)
+
+	actual = testStruct{}
+	Sanitize(payload).Params("age").Trim("!").TrimSpace().ToInt(&actual)
+	assert.Equal(t, testStruct{}, actual)
+}
+
+func TestSanitizeTransformTag(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"name":    "ken   chen",
+		"code":    "ａb1",
+		"comment": "a-b",
+	}}
+	expect := transformStruct{Name: "Ken Chen", Code: "AB1", Comment: "a_b"}
+	actual := transformStruct{}
+	Sanitize(payload).Params("name").ToString(&actual)
+	Sanitize(payload).Params("code").ToString(&actual)
+	Sanitize(payload).Params("comment").ToString(&actual)
+	assert.Equal(t, expect, actual)
+}
+
+func TestSanitizeTransformTagInvalid(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"name":    " ken ",
+		"comment": "a-b",
+	}}
+	actual := badTransformStruct{}
+	Sanitize(payload).Params("name").ToString(&actual)
+	Sanitize(payload).Params("comment").ToString(&actual)
+	errs, _ := ValidateResult(payload)
+	if assert.Equal(t, 2, len(errs)) {
+		assert.IsType(t, ConfigError{}, errs[0])
+		assert.EqualError(t, errs[0], "option trimspce of name is invalid")
+		assert.EqualError(t, errs[1], "option replace=- of comment is invalid")
+	}
+	assert.Equal(t, badTransformStruct{}, actual)
+}
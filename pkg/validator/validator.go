@@ -1,14 +1,23 @@
 package validator
 
 import (
-	"reflect"
+	"fmt"
+	"time"
 )
 
 const (
-	contextKey = "github.com/govalidator/validator"
-	abcenseKey = "abcense"
-	errorsKey  = "errors"
-	structKey  = "struct"
+	contextKey   = "github.com/govalidator/validator"
+	abcenseKey   = "abcense"
+	errorsKey    = "errors"
+	structKey    = "struct"
+	invalidKey   = "invalid"
+	coveredKey   = "covered"
+	strictKey    = "strict"
+	failFastKey  = "failFast"
+	localeKey    = "locale"
+	utcKey       = "utc"
+	clockKey     = "clock"
+	sanitizedKey = "sanitized"
 )
 
 const (
@@ -25,6 +34,8 @@ const (
 	ipType
 	timeType
 	localTimeType
+	locationType
+	durationType
 )
 
 // Payload is payload of message, it will store some info of validator, so you have to
@@ -35,18 +46,58 @@ type Payload interface {
 	GetParam(string) (val interface{}, exist bool)
 }
 
+// ParamLister is optional interface of payload, it lists all params the message carries,
+// so that strict mode can find the params no one asks for.
+type ParamLister interface {
+	Params() []string
+}
+
+// Clock provide the reference time of time-relative rules, like InFuture and WithinLast
+type Clock interface {
+	Now() time.Time
+}
+
 // ValidateResult validate result of sanitize
 func ValidateResult(payload Payload) (formatError []error, absence []string) {
 	cache := payload.GetCache()
 	errorList := cache[contextKey].(map[string]interface{})[errorsKey].([]error)
 	absenceList := cache[contextKey].(map[string]interface{})[abcenseKey].([]string)
+	errorList = validateStructs(payload, errorList)
+	if strict, _ := cache[contextKey].(map[string]interface{})[strictKey].(bool); strict {
+		covered, _ := cache[contextKey].(map[string]interface{})[coveredKey].(map[string]bool)
+		unknownList := unknownParams(payload, covered)
+		if failFast, _ := cache[contextKey].(map[string]interface{})[failFastKey].(bool); failFast {
+			if len(errorList) > 0 {
+				unknownList = nil
+			} else if len(unknownList) > 1 {
+				unknownList = unknownList[:1]
+			}
+		}
+		errorList = append(errorList, unknownList...)
+	}
+	errorList = localizeErrors(payload, errorList)
 	cache[contextKey] = make(map[string]interface{})
 	return errorList, absenceList
 }
 
+func unknownParams(payload Payload, covered map[string]bool) []error {
+	lister, ok := payload.(ParamLister)
+	if !ok {
+		return nil
+	}
+	var errorList []error
+	for _, param := range lister.Params() {
+		if !covered[param] {
+			errorList = append(errorList, newUnknownParamError(fmt.Sprintf("field %s is unknown", param), "unknown", "param", param))
+		}
+	}
+	return errorList
+}
+
 // AnalyzeType is type to validate
 type AnalyzeType struct {
-	content interface{}
+	content     interface{}
+	timeFormats []string
 }
 
 // Analyze a struct
@@ -56,18 +107,30 @@ func Analyze(structTagged interface{}) *AnalyzeType {
 	}
 }
 
-// Fields get the tagged field name
+// Fields get the tagged field name, the content can be struct or pointer to struct
 func (v *AnalyzeType) Fields(tags []string) []string {
 	tagsMap := make(map[string]bool)
 	for _, tag := range tags {
 		tagsMap[tag] = true
 	}
 	fieldNames := []string{}
-	contentType := reflect.TypeOf(v.content)
-	for i := 0; i < contentType.NumField(); i++ {
-		if _, ok := tagsMap[contentType.Field(i).Tag.Get("vld")]; ok {
-			fieldNames = append(fieldNames, contentType.Field(i).Name)
+	info := structInfoOfTarget(v.content)
+	if info == nil {
+		return fieldNames
+	}
+	for _, param := range info.tags {
+		if _, ok := tagsMap[param]; ok {
+			fieldNames = append(fieldNames, info.byParam[param].name)
 		}
 	}
 	return fieldNames
 }
+
+// Tags get all vld tags of the struct
+func (v *AnalyzeType) Tags() []string {
+	info := structInfoOfTarget(v.content)
+	if info == nil {
+		return []string{}
+	}
+	return append([]string{}, info.tags...)
+}
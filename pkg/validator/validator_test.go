@@ -3,6 +3,7 @@ package validator
 import (
 	"net"
 	"reflect"
+	"sort"
 	"testing"
 	"time"
 
@@ -30,6 +31,19 @@ func (m *message) GetParam(field string) (val interface{}, exist bool) {
 	return v, ok
 }
 
+type listedMessage struct {
+	message
+}
+
+func (m *listedMessage) Params() []string {
+	params := []string{}
+	for param := range m.msg {
+		params = append(params, param)
+	}
+	sort.Strings(params)
+	return params
+}
+
 type leg struct {
 	Number int `json:"number"`
 }
@@ -254,3 +268,180 @@ func TestAnalyze(t *testing.T) {
 	actual := Analyze(person).Fields(tags)
 	assert.Equal(t, expect, actual)
 }
+
+func TestValidateStrict(t *testing.T) {
+	type testCase struct {
+		dataReq     *listedMessage
+		wantUnknown []string
+	}
+	cases := []testCase{
+		{
+			dataReq: &listedMessage{message{msg: map[string]interface{}{
+				"age":   "18",
+				"score": "60",
+			}}},
+			wantUnknown: []string{"score"},
+		},
+		{
+			dataReq: &listedMessage{message{msg: map[string]interface{}{
+				"age":   "18",
+				"name":  "ken",
+				"extra": "1",
+				"more":  "2",
+			}}},
+			wantUnknown: []string{"extra", "more"},
+		},
+	}
+	for _, tc := range cases {
+		Check(tc.dataReq).Strict().Params("age").IsExist()
+		Sanitize(tc.dataReq).Params("age").ToInt(&struct {
+			Age  int    `vld:"age"`
+			Name string `vld:"name"`
+		}{})
+		errs, _ := ValidateResult(tc.dataReq)
+		assert.Equal(t, len(tc.wantUnknown), len(errs))
+		for i, err := range errs {
+			_, ok := err.(UnknownParamError)
+			assert.True(t, ok)
+			assert.Contains(t, err.Error(), tc.wantUnknown[i])
+		}
+	}
+}
+
+func TestValidateStrictCompare(t *testing.T) {
+	payload := &listedMessage{message{msg: map[string]interface{}{
+		"password":         "secret",
+		"password_confirm": "secret",
+		"start":            "1",
+		"end":              "2",
+	}}}
+	Check(payload).Strict().Params("password").EqualTo("password_confirm")
+	values := map[string]interface{}{}
+	Sanitize(payload).Params("start").ToInt(values).LessThan("end")
+	errs, _ := ValidateResult(payload)
+	assert.Equal(t, 1, len(errs))
+	assert.EqualError(t, errs[0], "field end should be sanitized before comparing with start")
+	assert.IsType(t, ConfigError{}, errs[0])
+
+	Check(payload).Strict().Params("password").EqualTo("password_confirm")
+	Sanitize(payload).Params("end").ToInt(values)
+	Sanitize(payload).Params("start").ToInt(values).LessThan("end")
+	errs, _ = ValidateResult(payload)
+	assert.Equal(t, []error{}, errs)
+}
+
+func TestValidateNotStrict(t *testing.T) {
+	payload := &listedMessage{message{msg: map[string]interface{}{
+		"age":   "18",
+		"score": "60",
+	}}}
+	Check(payload).Params("age").IsExist()
+	errs, _ := ValidateResult(payload)
+	assert.Equal(t, 0, len(errs))
+}
+
+func TestAnalyzeTags(t *testing.T) {
+	expect := []string{"number"}
+	actual := Analyze(&struct {
+		Number int `vld:"number"`
+		Other  int
+	}{}).Tags()
+	assert.Equal(t, expect, actual)
+}
+
+func TestValidateFailFast(t *testing.T) {
+	type testCase struct {
+		dataReq         *message
+		wantAbsence     int
+		wantFormatError int
+	}
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"age": "18A",
+			}},
+			wantAbsence:     0,
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"score": "60A",
+			}},
+			wantAbsence:     1,
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"age":   "18",
+				"score": "60",
+			}},
+			wantAbsence:     0,
+			wantFormatError: 0,
+		},
+	}
+	for _, tc := range cases {
+		actual := testStruct{}
+		Sanitize(tc.dataReq).FailFast().Params("age").ToInt(&actual)
+		Sanitize(tc.dataReq).Params("score").ToInt(&actual)
+		Check(tc.dataReq).Params("age").IsString()
+		errs, absence := ValidateResult(tc.dataReq)
+		assert.Equal(t, tc.wantFormatError, len(errs))
+		assert.Equal(t, tc.wantAbsence, len(absence))
+	}
+}
+
+func TestValidateBail(t *testing.T) {
+	type testCase struct {
+		dataReq         *message
+		bail            bool
+		wantAbsence     int
+		wantFormatError int
+	}
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"age": "18",
+			}},
+			bail:            true,
+			wantAbsence:     0,
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"age": "18",
+			}},
+			bail:            false,
+			wantAbsence:     0,
+			wantFormatError: 3,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"score": "60",
+			}},
+			bail:            true,
+			wantAbsence:     1,
+			wantFormatError: 1,
+		},
+	}
+	for _, tc := range cases {
+		check := Check(tc.dataReq).Params("age")
+		if tc.bail {
+			check = check.Bail()
+		}
+		check.IsInt().IsFloat().IsBool().IsString()
+		errs, absence := ValidateResult(tc.dataReq)
+		assert.Equal(t, tc.wantFormatError, len(errs))
+		assert.Equal(t, tc.wantAbsence, len(absence))
+	}
+}
+
+func TestValidateFailFastStrict(t *testing.T) {
+	payload := &listedMessage{message{msg: map[string]interface{}{
+		"age":   "18",
+		"extra": "1",
+		"more":  "2",
+	}}}
+	Check(payload).Strict().FailFast().Params("age").IsExist()
+	errs, _ := ValidateResult(payload)
+	assert.Equal(t, 1, len(errs))
+}
@@ -0,0 +1,27 @@
+// Package validatortest provides helpers for testing code that uses validator.
+package validatortest
+
+import (
+	"time"
+)
+
+// FixedClock is a clock that always returns the same time, it makes time-relative rules
+// deterministic in tests
+type FixedClock struct {
+	now time.Time
+}
+
+// NewFixedClock return a clock fixed at the time
+func NewFixedClock(now time.Time) *FixedClock {
+	return &FixedClock{now: now}
+}
+
+// Now return the fixed time
+func (c *FixedClock) Now() time.Time {
+	return c.now
+}
+
+// Advance move the fixed time forward by the duration
+func (c *FixedClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
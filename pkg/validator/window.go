@@ -0,0 +1,136 @@
+package validator
+
+import (
+	"fmt"
+	"time"
+)
+
+// windowRule check the value of param, the value should be time or duration
+type windowRule func(param string, val interface{}) error
+
+// NotBefore check param is not before the time
+func (v *CheckType) NotBefore(t time.Time) *CheckType {
+	return v.windowRule(notBefore(t))
+}
+
+// NotAfter check param is not after the time
+func (v *CheckType) NotAfter(t time.Time) *CheckType {
+	return v.windowRule(notAfter(t))
+}
+
+// WithinLast check param is in the last duration until now
+func (v *CheckType) WithinLast(d time.Duration) *CheckType {
+	return v.windowRule(withinLast(v.now(), d))
+}
+
+// InFuture check param is after now
+func (v *CheckType) InFuture() *CheckType {
+	return v.windowRule(inFuture(v.now()))
+}
+
+// DurationBetween check param is duration between min and max
+func (v *CheckType) DurationBetween(min, max time.Duration) *CheckType {
+	return v.windowRule(durationBetween(min, max))
+}
+
+func (v *CheckType) windowRule(rule windowRule) *CheckType {
+	val, exist := v.handleAbsence()
+	if exist {
+		v.handleErrors(rule(v.getParam(), val))
+	}
+	return v
+}
+
+// NotBefore check sanitized time is not before the time
+func (v *SanitizeType) NotBefore(t time.Time) *SanitizeType {
+	return v.windowRule(notBefore(t))
+}
+
+// NotAfter check sanitized time is not after the time
+func (v *SanitizeType) NotAfter(t time.Time) *SanitizeType {
+	return v.windowRule(notAfter(t))
+}
+
+// WithinLast check sanitized time is in the last duration until now
+func (v *SanitizeType) WithinLast(d time.Duration) *SanitizeType {
+	return v.windowRule(withinLast(v.now(), d))
+}
+
+// InFuture check sanitized time is after now
+func (v *SanitizeType) InFuture() *SanitizeType {
+	return v.windowRule(inFuture(v.now()))
+}
+
+// DurationBetween check sanitized duration is between min and max
+func (v *SanitizeType) DurationBetween(min, max time.Duration) *SanitizeType {
+	return v.windowRule(durationBetween(min, max))
+}
+
+func (v *SanitizeType) windowRule(rule windowRule) *SanitizeType {
+	if v.out == nil || v.skip() || v.isBroken(v.getParam()) {
+		return v
+	}
+	if val, ok := fieldValue(v.out, v.param); ok {
+		v.handleErrors(rule(v.getParam(), val))
+	}
+	return v
+}
+
+func notBefore(t time.Time) windowRule {
+	return timeRule(func(param string, val time.Time) error {
+		if val.Before(t) {
+			return newInvalidValueError(fmt.Sprintf("field %s is before %v", param, t), "not_before", "param", param, "limit", t)
+		}
+		return nil
+	})
+}
+
+func notAfter(t time.Time) windowRule {
+	return timeRule(func(param string, val time.Time) error {
+		if val.After(t) {
+			return newInvalidValueError(fmt.Sprintf("field %s is after %v", param, t), "not_after", "param", param, "limit", t)
+		}
+		return nil
+	})
+}
+
+func withinLast(now time.Time, d time.Duration) windowRule {
+	return timeRule(func(param string, val time.Time) error {
+		if val.Before(now.Add(-d)) || val.After(now) {
+			return newInvalidValueError(fmt.Sprintf("field %s is not within last %v", param, d), "within_last", "param", param, "limit", d)
+		}
+		return nil
+	})
+}
+
+func inFuture(now time.Time) windowRule {
+	return timeRule(func(param string, val time.Time) error {
+		if !val.After(now) {
+			return newInvalidValueError(fmt.Sprintf("field %s is not in future", param), "future", "param", param)
+		}
+		return nil
+	})
+}
+
+func durationBetween(min, max time.Duration) windowRule {
+	return func(param string, val interface{}) error {
+		duration, ok := val.(time.Duration)
+		if !ok {
+			return newTypeError(param, "duration")
+		}
+		if duration < min || duration > max {
+			return newInvalidValueError(fmt.Sprintf("field %s is not between %v and %v", param, min, max), "duration_between", "param", param, "min", min, "max", max)
+		}
+		return nil
+	}
+}
+
+func timeRule(rule func(param string, val time.Time) error) windowRule {
+	return func(param string, val interface{}) error {
+		timeVal, ok := val.(time.Time)
+		if !ok {
+			return newTypeError(param, "time")
+		}
+		return rule(param, timeVal)
+	}
+}
@@ -0,0 +1,138 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ken00535/validator/pkg/validator/validatortest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTimeWindow(t *testing.T) {
+	type testCase struct {
+		dataReq         *message
+		rule            func(v *CheckType) *CheckType
+		wantFormatError int
+	}
+	now := time.Now()
+	cases := []testCase{
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"expiry": now.Add(time.Hour),
+			}},
+			rule:            (*CheckType).InFuture,
+			wantFormatError: 0,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"expiry": now.Add(-time.Hour),
+			}},
+			rule:            (*CheckType).InFuture,
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"expiry": now.Add(-time.Hour),
+			}},
+			rule:            func(v *CheckType) *CheckType { return v.WithinLast(24 * time.Hour) },
+			wantFormatError: 0,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"expiry": now.Add(-48 * time.Hour),
+			}},
+			rule:            func(v *CheckType) *CheckType { return v.WithinLast(24 * time.Hour) },
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"expiry": now,
+			}},
+			rule:            func(v *CheckType) *CheckType { return v.NotBefore(now.Add(time.Minute)) },
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"expiry": now,
+			}},
+			rule:            func(v *CheckType) *CheckType { return v.NotAfter(now) },
+			wantFormatError: 0,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"expiry": "2020-11-06",
+			}},
+			rule:            func(v *CheckType) *CheckType { return v.NotAfter(now) },
+			wantFormatError: 1,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"expiry": time.Minute,
+			}},
+			rule:            func(v *CheckType) *CheckType { return v.DurationBetween(time.Second, time.Hour) },
+			wantFormatError: 0,
+		},
+		{
+			dataReq: &message{msg: map[string]interface{}{
+				"expiry": 2 * time.Hour,
+			}},
+			rule:            func(v *CheckType) *CheckType { return v.DurationBetween(time.Second, time.Hour) },
+			wantFormatError: 1,
+		},
+	}
+	for _, tc := range cases {
+		tc.rule(Check(tc.dataReq).Params("expiry"))
+		formatErrs, _ := ValidateResult(tc.dataReq)
+		assert.Equal(t, tc.wantFormatError, len(formatErrs))
+	}
+}
+
+func TestSanitizeTimeWindow(t *testing.T) {
+	payload := &message{msg: map[string]interface{}{
+		"startTime": "2020-11-06T16:19:23Z",
+		"timeout":   "PT1H",
+	}}
+	actual := testStruct{}
+	Sanitize(payload).Params("startTime").ToTime(&actual).
+		NotBefore(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)).
+		NotAfter(time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC))
+	formatErrs, _ := ValidateResult(payload)
+	assert.Equal(t, 0, len(formatErrs))
+
+	Sanitize(payload).Params("startTime").ToTime(&actual).InFuture()
+	formatErrs, _ = ValidateResult(payload)
+	assert.Equal(t, 1, len(formatErrs))
+	_, ok := formatErrs[0].(InvalidValueError)
+	assert.True(t, ok)
+
+	timeout := durationStruct{}
+	Sanitize(payload).Params("timeout").ToDuration(&timeout).DurationBetween(time.Minute, 2*time.Hour)
+	formatErrs, _ = ValidateResult(payload)
+	assert.Equal(t, 0, len(formatErrs))
+
+	Sanitize(payload).Params("timeout").ToDuration(&timeout).DurationBetween(time.Minute, time.Minute)
+	formatErrs, _ = ValidateResult(payload)
+	assert.Equal(t, 1, len(formatErrs))
+}
+
+func TestTimeWindowClock(t *testing.T) {
+	clock := validatortest.NewFixedClock(time.Date(2020, 11, 6, 0, 0, 0, 0, time.UTC))
+	payload := &message{msg: map[string]interface{}{
+		"startTime": "2020-11-06T16:19:23Z",
+	}}
+	actual := testStruct{}
+	Sanitize(payload).WithClock(clock).Params("startTime").ToTime(&actual).InFuture().WithinLast(time.Hour)
+	formatErrs, _ := ValidateResult(payload)
+	assert.Equal(t, 1, len(formatErrs))
+
+	clock.Advance(17 * time.Hour)
+	Sanitize(payload).WithClock(clock).Params("startTime").ToTime(&actual).WithinLast(time.Hour)
+	Check(payload).Params("startTime").IsString()
+	formatErrs, _ = ValidateResult(payload)
+	assert.Equal(t, 0, len(formatErrs))
+
+	Check(payload).WithClock(clock).Params("expiry").InFuture()
+	formatErrs, absence := ValidateResult(payload)
+	assert.Equal(t, 1, len(formatErrs))
+	assert.Equal(t, 1, len(absence))
+}